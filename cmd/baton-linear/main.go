@@ -42,17 +42,10 @@ func main() {
 	}
 }
 
-func getConnector(ctx context.Context, lc *cfg.Linear, runTimeOpts cli.RunTimeOpts) (types.ConnectorServer, error) {
+func getConnector(ctx context.Context, lc *cfg.Linear, _ cli.RunTimeOpts) (types.ConnectorServer, error) {
 	l := ctxzap.Extract(ctx)
 
-	// Role grants are emitted from the user syncer, so the user builder needs
-	// to know whether the customer's sync filter includes the role resource
-	// type. WillSyncResourceType returns true when roles are explicitly
-	// selected or when no filter is set at all (e.g. local CLI runs).
-	connectorOpts := &cli.ConnectorOpts{SyncResourceTypeIDs: runTimeOpts.SyncResourceTypeIDs}
-	syncRoles := connectorOpts.WillSyncResourceType(connector.RoleResourceTypeID)
-
-	cb, err := connector.New(ctx, lc.ApiKey, lc.SkipProjects, lc.TicketSchemaTeamIdsFilter, lc.BaseUrl, syncRoles)
+	cb, err := connector.New(ctx, lc.ApiKey, lc.SkipProjects, lc.SkipPrivateTeams, lc.SkipAppUsers, lc.TicketSchemaTeamIdsFilter, lc.BaseUrl)
 	if err != nil {
 		l.Error("error creating connector", zap.Error(err))
 		return nil, err
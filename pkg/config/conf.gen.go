@@ -7,6 +7,8 @@ type Linear struct {
 	ApiKey string `mapstructure:"api-key"`
 	Ticketing bool `mapstructure:"ticketing"`
 	SkipProjects bool `mapstructure:"skip-projects"`
+	SkipPrivateTeams bool `mapstructure:"skip-private-teams"`
+	SkipAppUsers bool `mapstructure:"skip-app-users"`
 	TicketSchemaTeamIdsFilter []string `mapstructure:"ticket-schema-team-ids-filter"`
 	BaseUrl string `mapstructure:"base-url"`
 }
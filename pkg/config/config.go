@@ -17,6 +17,16 @@ var (
 		field.WithDisplayName("Skip projects"),
 		field.WithDescription("Skip syncing projects."),
 	)
+	skipPrivateTeams = field.BoolField(
+		"skip-private-teams",
+		field.WithDisplayName("Skip private teams"),
+		field.WithDescription("Skip syncing private teams."),
+	)
+	skipAppUsers = field.BoolField(
+		"skip-app-users",
+		field.WithDisplayName("Skip app users"),
+		field.WithDescription("Skip syncing app and bot users."),
+	)
 	teamIDsTicketSchemaFilterField = field.StringSliceField(
 		"ticket-schema-team-ids-filter",
 		field.WithDisplayName("Teams"),
@@ -37,7 +47,7 @@ var configRelations = []field.SchemaFieldRelationship{
 
 //go:generate go run ./gen
 var Config = field.NewConfiguration(
-	[]field.SchemaField{apiKey, externalTicketField, skipProjects, teamIDsTicketSchemaFilterField, baseURLField},
+	[]field.SchemaField{apiKey, externalTicketField, skipProjects, skipPrivateTeams, skipAppUsers, teamIDsTicketSchemaFilterField, baseURLField},
 	field.WithConstraints(configRelations...),
 	field.WithConnectorDisplayName("Linear"),
 	field.WithHelpUrl("/docs/baton/linear"),
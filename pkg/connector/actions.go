@@ -0,0 +1,301 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-linear/pkg/linear"
+	config "github.com/conductorone/baton-sdk/pb/c1/config/v1"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/actions"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var _ connectorbuilder.GlobalActionProvider = (*Linear)(nil)
+
+const (
+	transferOwnershipActionName = "transfer_ownership"
+	offboardUserActionName      = "offboard_user"
+
+	// issueBatchSize is the most issues Linear updates in one issueBatchUpdate.
+	issueBatchSize = 50
+)
+
+var transferOwnershipActionSchema = &v2.BatonActionSchema{
+	Name:        transferOwnershipActionName,
+	DisplayName: "Transfer workspace ownership",
+	Description: "Make a user an owner of the Linear workspace. Unless the current owner is kept, the owner the API key belongs to becomes an admin.",
+	Arguments: []*config.Field{
+		{
+			Name:        "resource_id",
+			DisplayName: "User",
+			Description: "The user to make an owner.",
+			Field:       &config.Field_ResourceIdField{ResourceIdField: &config.ResourceIdField{}},
+			IsRequired:  true,
+		},
+		{
+			Name:        "keep_current_owner",
+			DisplayName: "Keep current owner",
+			Description: "Add the user as another owner instead of transferring ownership away from the API key's owner.",
+			Field:       &config.Field_BoolField{BoolField: &config.BoolField{}},
+		},
+	},
+	ReturnTypes: []*config.Field{
+		{
+			Name:        "success",
+			DisplayName: "Success",
+			Field:       &config.Field_BoolField{BoolField: &config.BoolField{}},
+		},
+		{
+			Name:        "previous_owner_demoted",
+			DisplayName: "Previous owner demoted",
+			Field:       &config.Field_BoolField{BoolField: &config.BoolField{}},
+		},
+	},
+	ActionType: []v2.ActionType{
+		v2.ActionType_ACTION_TYPE_DYNAMIC,
+	},
+}
+
+var offboardUserActionSchema = &v2.BatonActionSchema{
+	Name:        offboardUserActionName,
+	DisplayName: "Offboard user",
+	Description: "Reassign a user's open issues to a replacement, or unassign them if no replacement is given, and optionally suspend the user.",
+	Arguments: []*config.Field{
+		{
+			Name:        "resource_id",
+			DisplayName: "User",
+			Description: "The departing user.",
+			Field:       &config.Field_ResourceIdField{ResourceIdField: &config.ResourceIdField{}},
+			IsRequired:  true,
+		},
+		{
+			Name:        "replacement_id",
+			DisplayName: "Replacement",
+			Description: "The user to reassign open issues to. Issues are unassigned when empty.",
+			Field:       &config.Field_ResourceIdField{ResourceIdField: &config.ResourceIdField{}},
+		},
+		{
+			Name:        "suspend",
+			DisplayName: "Suspend user",
+			Description: "Suspend the user once their issues are reassigned.",
+			Field:       &config.Field_BoolField{BoolField: &config.BoolField{}},
+		},
+	},
+	ReturnTypes: []*config.Field{
+		{
+			Name:        "success",
+			DisplayName: "Success",
+			Field:       &config.Field_BoolField{BoolField: &config.BoolField{}},
+		},
+		{
+			Name:        "reassigned",
+			DisplayName: "Issues reassigned",
+			Field:       &config.Field_IntField{IntField: &config.IntField{}},
+		},
+		{
+			Name:        "unassigned",
+			DisplayName: "Issues unassigned",
+			Field:       &config.Field_IntField{IntField: &config.IntField{}},
+		},
+		{
+			Name:        "suspended",
+			DisplayName: "Suspended",
+			Field:       &config.Field_BoolField{BoolField: &config.BoolField{}},
+		},
+	},
+	ActionType: []v2.ActionType{
+		v2.ActionType_ACTION_TYPE_DYNAMIC,
+	},
+}
+
+func (ln *Linear) GlobalActions(ctx context.Context, registry actions.ActionRegistry) error {
+	if err := registry.Register(ctx, transferOwnershipActionSchema, ln.transferOwnership); err != nil {
+		return err
+	}
+	return registry.Register(ctx, offboardUserActionSchema, ln.offboardUser)
+}
+
+// transferOwnership makes a user a workspace owner. Only owners can change
+// ownership, so the API key has to belong to one; that owner is the one
+// demoted to admin when ownership is transferred rather than shared.
+func (ln *Linear) transferOwnership(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+	resourceId, err := actions.RequireResourceIDArg(args, "resource_id")
+	if err != nil {
+		return nil, nil, err
+	}
+	if resourceId.GetResourceType() != resourceTypeUser.Id {
+		return nil, nil, fmt.Errorf("baton-linear: non-user resource passed to transfer ownership: %s", resourceId.GetResourceType())
+	}
+	keepCurrentOwner, _ := actions.GetBoolArg(args, "keep_current_owner")
+
+	viewer, _, err := ln.client.Authorize(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-linear: failed to get API key permissions: %w", err)
+	}
+	if !viewer.Owner {
+		return nil, nil, fmt.Errorf("baton-linear: the API key must belong to a workspace owner to transfer ownership")
+	}
+
+	user, _, err := ln.client.GetUser(ctx, resourceId.GetResource())
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-linear: failed to get user: %w", err)
+	}
+	if !user.Active {
+		return nil, nil, fmt.Errorf("baton-linear: can't make suspended user %s an owner", user.ID)
+	}
+
+	// Everything is checked before the first role change, so a refusal never
+	// leaves the workspace half transferred.
+	demote := !keepCurrentOwner && viewer.ID != user.ID
+	if demote {
+		otherOwners, err := countActiveUsers(ctx, ln.client, func(u *linear.User) bool { return u.Owner && u.ID != viewer.ID })
+		if err != nil {
+			return nil, nil, err
+		}
+		if !user.Owner {
+			otherOwners++
+		}
+		if otherOwners < 1 {
+			return nil, nil, status.Error(codes.FailedPrecondition, "baton-linear: refusing to demote the last workspace owner")
+		}
+	}
+
+	if !user.Owner {
+		success, err := ln.client.SetUserRole(ctx, user.ID, roleOwner)
+		if err != nil {
+			return nil, nil, fmt.Errorf("baton-linear: failed to make user an owner: %w", err)
+		}
+		if !success {
+			return nil, nil, fmt.Errorf("baton-linear: userChangeRole returned success=false")
+		}
+	}
+
+	if !demote {
+		return actions.NewReturnValues(true, actions.NewBoolReturnField("previous_owner_demoted", false)), nil, nil
+	}
+
+	success, err := ln.client.SetUserRole(ctx, viewer.ID, roleAdmin)
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-linear: failed to demote previous owner: %w", err)
+	}
+	if !success {
+		return nil, nil, fmt.Errorf("baton-linear: userChangeRole returned success=false")
+	}
+
+	return actions.NewReturnValues(true, actions.NewBoolReturnField("previous_owner_demoted", true)), nil, nil
+}
+
+// offboardUser hands a departing user's open issues to a replacement, or
+// unassigns them, so they don't sit on a suspended account.
+func (ln *Linear) offboardUser(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+	resourceId, err := actions.RequireResourceIDArg(args, "resource_id")
+	if err != nil {
+		return nil, nil, err
+	}
+	if resourceId.GetResourceType() != resourceTypeUser.Id {
+		return nil, nil, fmt.Errorf("baton-linear: non-user resource passed to offboard user: %s", resourceId.GetResourceType())
+	}
+	suspend, _ := actions.GetBoolArg(args, "suspend")
+
+	replacementID := ""
+	if replacementId, ok := actions.GetResourceIDArg(args, "replacement_id"); ok && replacementId.GetResource() != "" {
+		if replacementId.GetResourceType() != resourceTypeUser.Id {
+			return nil, nil, fmt.Errorf("baton-linear: non-user resource passed as replacement: %s", replacementId.GetResourceType())
+		}
+		if replacementId.GetResource() == resourceId.GetResource() {
+			return nil, nil, fmt.Errorf("baton-linear: a user can't be their own replacement")
+		}
+		replacement, _, err := ln.client.GetUser(ctx, replacementId.GetResource())
+		if err != nil {
+			return nil, nil, fmt.Errorf("baton-linear: failed to get replacement user: %w", err)
+		}
+		if !replacement.Active {
+			return nil, nil, fmt.Errorf("baton-linear: can't reassign issues to suspended user %s", replacement.ID)
+		}
+		replacementID = replacement.ID
+	}
+
+	// Refuse to suspend the last admin before any issue is touched, so a
+	// refused offboarding leaves everything as it was.
+	var user linear.User
+	if suspend {
+		user, _, err = ln.client.GetUser(ctx, resourceId.GetResource())
+		if err != nil {
+			return nil, nil, fmt.Errorf("baton-linear: failed to get user: %w", err)
+		}
+		if err := guardLastAdmin(ctx, ln.client, &user); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	issueIDs, err := openIssueIDs(ctx, ln.client, resourceId.GetResource())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for start := 0; start < len(issueIDs); start += issueBatchSize {
+		end := min(start+issueBatchSize, len(issueIDs))
+		success, err := ln.client.UpdateIssuesAssignee(ctx, issueIDs[start:end], replacementID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("baton-linear: failed to reassign issues: %w", err)
+		}
+		if !success {
+			return nil, nil, fmt.Errorf("baton-linear: issueBatchUpdate returned success=false")
+		}
+	}
+
+	reassigned, unassigned := len(issueIDs), 0
+	if replacementID == "" {
+		reassigned, unassigned = 0, len(issueIDs)
+	}
+
+	if suspend && user.Active {
+		success, err := ln.client.SuspendUser(ctx, user.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("baton-linear: failed to suspend user: %w", err)
+		}
+		if !success {
+			return nil, nil, fmt.Errorf("baton-linear: userSuspend returned success=false")
+		}
+	}
+
+	return actions.NewReturnValues(true,
+		newIntReturnField("reassigned", reassigned),
+		newIntReturnField("unassigned", unassigned),
+		actions.NewBoolReturnField("suspended", suspend),
+	), nil, nil
+}
+
+// newIntReturnField builds the value of an IntField return type. Structs have
+// no integer kind, so whole numbers travel as number values.
+func newIntReturnField(key string, value int) actions.ReturnField {
+	return actions.NewReturnField(key, structpb.NewNumberValue(float64(value)))
+}
+
+// openIssueIDs collects every issue assigned to the user that isn't completed
+// or canceled. They're gathered up front because reassigning them changes
+// what the filtered listing returns.
+func openIssueIDs(ctx context.Context, client *linear.Client, userID string) ([]string, error) {
+	closed := []linear.WorkflowType{linear.Completed, linear.Canceled}
+
+	var ids []string
+	after := ""
+	for {
+		issues, next, _, err := client.GetAssignedIssues(ctx, linear.GetAssignedIssuesVars{UserID: userID, ExcludedStates: closed, First: resourcePageSize, After: after})
+		if err != nil {
+			return nil, fmt.Errorf("baton-linear: failed to list assigned issues: %w", err)
+		}
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		if next == "" {
+			return ids, nil
+		}
+		after = next
+	}
+}
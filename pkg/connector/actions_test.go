@@ -0,0 +1,271 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newTestConnector(t *testing.T, handler http.HandlerFunc) *Linear {
+	return &Linear{client: newTestClient(t, handler)}
+}
+
+// ownershipTestServer answers the viewer, user and user list queries from
+// the given JSON and records the role changes it receives, in order.
+func ownershipTestServer(t *testing.T, viewerJSON, userJSON, usersJSON string, changes *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = decodeJSON(t, r, &req)
+		query := req["query"].(string)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(query, "query Viewer"):
+			_, _ = w.Write([]byte(`{"data":{"viewer":` + viewerJSON + `}}`))
+		case strings.Contains(query, "query User("):
+			_, _ = w.Write([]byte(`{"data":{"user":` + userJSON + `}}`))
+		case strings.Contains(query, "query Users("):
+			_, _ = w.Write([]byte(`{"data":{"users":{"nodes":` + usersJSON + `,"pageInfo":{"hasNextPage":false}}}}`))
+		case strings.Contains(query, "userChangeRole("):
+			vars := req["variables"].(map[string]interface{})
+			*changes = append(*changes, vars["id"].(string)+" "+vars["role"].(string))
+			_, _ = w.Write([]byte(`{"data":{"userChangeRole":{"success":true}}}`))
+		default:
+			t.Errorf("unexpected query: %s", query)
+		}
+	}
+}
+
+func transferOwnershipArgs(t *testing.T, userID string, keepCurrentOwner bool) *structpb.Struct {
+	t.Helper()
+	args, err := structpb.NewStruct(map[string]interface{}{
+		"resource_id": map[string]interface{}{
+			"resource_type_id": resourceTypeUser.Id,
+			"resource_id":      userID,
+		},
+		"keep_current_owner": keepCurrentOwner,
+	})
+	if err != nil {
+		t.Fatalf("failed to build args: %v", err)
+	}
+	return args
+}
+
+func TestTransferOwnership(t *testing.T) {
+	var changes []string
+	ln := newTestConnector(t, ownershipTestServer(t,
+		`{"id":"owner-1","owner":true}`,
+		`{"id":"u2","active":true}`,
+		`[{"id":"owner-1","active":true,"owner":true},{"id":"u2","active":true,"owner":true}]`,
+		&changes,
+	))
+
+	rv, _, err := ln.transferOwnership(context.Background(), transferOwnershipArgs(t, "u2", false))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 2 || changes[0] != "u2 owner" || changes[1] != "owner-1 admin" {
+		t.Errorf("expected u2 promoted then owner-1 demoted, got %v", changes)
+	}
+	if !rv.GetFields()["previous_owner_demoted"].GetBoolValue() {
+		t.Error("expected previous_owner_demoted to be true")
+	}
+}
+
+func TestTransferOwnership_KeepCurrentOwner(t *testing.T) {
+	var changes []string
+	ln := newTestConnector(t, ownershipTestServer(t,
+		`{"id":"owner-1","owner":true}`,
+		`{"id":"u2","active":true}`,
+		`[]`,
+		&changes,
+	))
+
+	rv, _, err := ln.transferOwnership(context.Background(), transferOwnershipArgs(t, "u2", true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 1 || changes[0] != "u2 owner" {
+		t.Errorf("expected only u2 to be promoted, got %v", changes)
+	}
+	if rv.GetFields()["previous_owner_demoted"].GetBoolValue() {
+		t.Error("expected previous_owner_demoted to be false")
+	}
+}
+
+func TestTransferOwnership_RequiresOwnerKey(t *testing.T) {
+	var changes []string
+	ln := newTestConnector(t, ownershipTestServer(t,
+		`{"id":"admin-1","admin":true}`,
+		`{"id":"u2","active":true}`,
+		`[]`,
+		&changes,
+	))
+
+	_, _, err := ln.transferOwnership(context.Background(), transferOwnershipArgs(t, "u2", false))
+	if err == nil {
+		t.Fatal("expected error when the API key doesn't belong to an owner")
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no role changes, got %v", changes)
+	}
+}
+
+func TestTransferOwnership_RefusesBeforeChangingRoles(t *testing.T) {
+	var changes []string
+	ln := newTestConnector(t, ownershipTestServer(t,
+		`{"id":"owner-1","owner":true}`,
+		`{"id":"u2","owner":true}`,
+		`[{"id":"owner-1","active":true,"owner":true}]`,
+		&changes,
+	))
+
+	_, _, err := ln.transferOwnership(context.Background(), transferOwnershipArgs(t, "u2", false))
+	if err == nil {
+		t.Fatal("expected error transferring ownership to a suspended owner")
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no role changes, got %v", changes)
+	}
+}
+
+// offboardTestServer serves the given number of open issues for the departing
+// user, in pages of two, and records the batch updates and suspensions it
+// receives. When lastAdmin is set, the departing user u1 is the workspace's
+// only admin.
+func offboardTestServer(t *testing.T, openIssues int, lastAdmin bool, calls *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = decodeJSON(t, r, &req)
+		query := req["query"].(string)
+		vars, _ := req["variables"].(map[string]interface{})
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(query, "query User("):
+			admin := lastAdmin && vars["id"] == "u1"
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{"user":{"id":%q,"active":true,"admin":%t}}}`, vars["id"], admin)))
+		case strings.Contains(query, "query Users("):
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{"users":{"nodes":[{"id":"u1","active":true,"admin":%t},{"id":"u2","active":true}],"pageInfo":{"hasNextPage":false}}}}`, lastAdmin)))
+		case strings.Contains(query, "query AssignedIssues("):
+			if vars["userId"] != "u1" {
+				t.Errorf("unexpected assignee: %v", vars["userId"])
+			}
+			excluded := vars["excludedStates"].([]interface{})
+			if len(excluded) != 2 || excluded[0] != "completed" || excluded[1] != "canceled" {
+				t.Errorf("unexpected excluded states: %v", excluded)
+			}
+			start := 0
+			if after, ok := vars["after"]; ok {
+				if after == "" {
+					t.Error("expected no cursor on the first page")
+				}
+				start = 2
+			}
+			var nodes []string
+			for i := start; i < openIssues && i < start+2; i++ {
+				nodes = append(nodes, `{"id":"issue-`+string(rune('a'+i))+`"}`)
+			}
+			hasNext := start+2 < openIssues
+			next := `"pageInfo":{"hasNextPage":false}`
+			if hasNext {
+				next = `"pageInfo":{"hasNextPage":true,"endCursor":"page-2"}`
+			}
+			_, _ = w.Write([]byte(`{"data":{"issues":{"nodes":[` + strings.Join(nodes, ",") + `],` + next + `}}}`))
+		case strings.Contains(query, "issueBatchUpdate("):
+			ids := vars["ids"].([]interface{})
+			assignee := vars["input"].(map[string]interface{})["assigneeId"]
+			*calls = append(*calls, fmt.Sprintf("update %d %v", len(ids), assignee))
+			_, _ = w.Write([]byte(`{"data":{"issueBatchUpdate":{"success":true}}}`))
+		case strings.Contains(query, "userSuspend("):
+			*calls = append(*calls, "suspend "+vars["id"].(string))
+			_, _ = w.Write([]byte(`{"data":{"userSuspend":{"success":true}}}`))
+		default:
+			t.Errorf("unexpected query: %s", query)
+		}
+	}
+}
+
+func offboardArgs(t *testing.T, replacementID string, suspend bool) *structpb.Struct {
+	t.Helper()
+	fields := map[string]interface{}{
+		"resource_id": map[string]interface{}{"resource_type_id": resourceTypeUser.Id, "resource_id": "u1"},
+		"suspend":     suspend,
+	}
+	if replacementID != "" {
+		fields["replacement_id"] = map[string]interface{}{"resource_type_id": resourceTypeUser.Id, "resource_id": replacementID}
+	}
+	args, err := structpb.NewStruct(fields)
+	if err != nil {
+		t.Fatalf("failed to build args: %v", err)
+	}
+	return args
+}
+
+func TestOffboardUser_Reassigns(t *testing.T) {
+	var calls []string
+	ln := newTestConnector(t, offboardTestServer(t, 3, false, &calls))
+
+	rv, _, err := ln.offboardUser(context.Background(), offboardArgs(t, "u2", false))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(calls) != 1 || calls[0] != "update 3 u2" {
+		t.Errorf("expected one batch reassigning 3 issues to u2, got %v", calls)
+	}
+	if got := rv.GetFields()["reassigned"].GetNumberValue(); got != 3 {
+		t.Errorf("expected 3 reassigned, got %v", got)
+	}
+	if got := rv.GetFields()["unassigned"].GetNumberValue(); got != 0 {
+		t.Errorf("expected 0 unassigned, got %v", got)
+	}
+}
+
+func TestOffboardUser_UnassignsAndSuspends(t *testing.T) {
+	var calls []string
+	ln := newTestConnector(t, offboardTestServer(t, 1, false, &calls))
+
+	rv, _, err := ln.offboardUser(context.Background(), offboardArgs(t, "", true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "update 1 <nil>" || calls[1] != "suspend u1" {
+		t.Errorf("expected the issue unassigned and then u1 suspended, got %v", calls)
+	}
+	if got := rv.GetFields()["unassigned"].GetNumberValue(); got != 1 {
+		t.Errorf("expected 1 unassigned, got %v", got)
+	}
+	if !rv.GetFields()["suspended"].GetBoolValue() {
+		t.Error("expected suspended to be true")
+	}
+}
+
+func TestOffboardUser_RefusesLastAdminBeforeReassigning(t *testing.T) {
+	var calls []string
+	ln := newTestConnector(t, offboardTestServer(t, 3, true, &calls))
+
+	_, _, err := ln.offboardUser(context.Background(), offboardArgs(t, "", true))
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for the last admin, got %v", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("expected no issues to be touched, got %v", calls)
+	}
+}
+
+func TestOffboardUser_RejectsSelfReplacement(t *testing.T) {
+	var calls []string
+	ln := newTestConnector(t, offboardTestServer(t, 1, false, &calls))
+
+	_, _, err := ln.offboardUser(context.Background(), offboardArgs(t, "u1", false))
+	if err == nil {
+		t.Fatal("expected error when a user replaces themselves")
+	}
+	if len(calls) != 0 {
+		t.Errorf("expected no updates, got %v", calls)
+	}
+}
@@ -10,11 +10,6 @@ import (
 	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
 )
 
-// RoleResourceTypeID is exported because main.go uses it with
-// cli.ConnectorOpts.WillSyncResourceType to decide whether role grants
-// should be emitted from the user syncer.
-const RoleResourceTypeID = "role"
-
 var (
 	resourceTypeUser = &v2.ResourceType{
 		Id:          "user",
@@ -22,9 +17,9 @@ var (
 		Traits: []v2.ResourceType_Trait{
 			v2.ResourceType_TRAIT_USER,
 		},
-		// userBuilder clones this and adds SkipEntitlements by default, or
-		// SkipEntitlementsAndGrants when roles aren't being synced. Any
-		// annotations declared here are preserved on the clone.
+		// Role memberships are emitted by the role syncer, so users have
+		// neither entitlements nor grants of their own.
+		Annotations: annotationsSkipEntitlementsAndGrants(),
 	}
 	resourceTypeTeam = &v2.ResourceType{
 		Id:          "team",
@@ -41,33 +36,65 @@ var (
 		DisplayName: "Org",
 	}
 	resourceTypeRole = &v2.ResourceType{
-		Id:          RoleResourceTypeID,
+		Id:          "role",
 		DisplayName: "Role",
 		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_ROLE},
 	}
+	resourceTypeInitiative = &v2.ResourceType{
+		Id:          "initiative",
+		DisplayName: "Initiative",
+	}
+	resourceTypeInvite = &v2.ResourceType{
+		Id:          "invite",
+		DisplayName: "Invite",
+		// Invites carry no access until they are accepted.
+		Annotations: annotationsSkipEntitlementsAndGrants(),
+	}
+	resourceTypeOAuthApp = &v2.ResourceType{
+		Id:          "oauth_app",
+		DisplayName: "OAuth Application",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_APP},
+	}
+	resourceTypeIntegration = &v2.ResourceType{
+		Id:          "integration",
+		DisplayName: "Integration",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_APP},
+	}
+	resourceTypeWebhook = &v2.ResourceType{
+		Id:          "webhook",
+		DisplayName: "Webhook",
+	}
 )
 
+func annotationsSkipEntitlementsAndGrants() annotations.Annotations {
+	annos := annotations.Annotations{}
+	annos.Update(&v2.SkipEntitlementsAndGrants{})
+	return annos
+}
+
 type Linear struct {
 	client              *linear.Client
 	skipProjects        bool
+	skipPrivateTeams    bool
+	skipAppUsers        bool
 	ticketSchemaTeamIDs []string
-	// skipRoleGrants is true when the customer's sync filter excludes the
-	// role resource type. The zero value (false) preserves the default
-	// behavior, so the zero-value Linear used as the capabilities stub in
-	// main.go advertises the standard user resource type.
-	skipRoleGrants bool
 }
 
 func (ln *Linear) ResourceSyncers(ctx context.Context) []connectorbuilder.ResourceSyncer {
 	resourceSyncers := []connectorbuilder.ResourceSyncer{
-		userBuilder(ln.client, ln.skipRoleGrants),
-		teamBuilder(ln.client),
-		orgBuilder(ln.client),
-		roleBuilder(ln.client),
+		userBuilder(ln.client, ln.skipAppUsers),
+		teamBuilder(ln.client, ln.skipPrivateTeams, ln.skipAppUsers),
+		orgBuilder(ln.client, ln.skipPrivateTeams, ln.skipAppUsers),
+		roleBuilder(ln.client, ln.skipAppUsers),
+		inviteBuilder(ln.client),
+		oauthAppBuilder(ln.client),
+		integrationBuilder(ln.client, ln.skipPrivateTeams, ln.skipAppUsers),
+		webhookBuilder(ln.client, ln.skipPrivateTeams, ln.skipAppUsers),
+		initiativeBuilder(ln.client, ln.skipProjects, ln.skipAppUsers),
 	}
 
 	if !ln.skipProjects {
-		resourceSyncers = append(resourceSyncers, projectBuilder(ln.client))
+		resourceSyncers = append(resourceSyncers, projectBuilder(ln.client, ln.skipPrivateTeams, ln.skipAppUsers))
 	}
 
 	return resourceSyncers
@@ -77,7 +104,7 @@ func (ln *Linear) ResourceSyncers(ctx context.Context) []connectorbuilder.Resour
 func (ln *Linear) Metadata(ctx context.Context) (*v2.ConnectorMetadata, error) {
 	return &v2.ConnectorMetadata{
 		DisplayName: "Linear",
-		Description: "Connector sycing orgs, projects, teams, users and roles from Linear to Baton.",
+		Description: "Connector sycing orgs, projects, initiatives, teams, users, roles, integrations, webhooks and authorized OAuth applications from Linear to Baton.",
 	}, nil
 }
 
@@ -91,11 +118,8 @@ func (ln *Linear) Validate(ctx context.Context) (annotations.Annotations, error)
 	return nil, nil
 }
 
-// New returns the Linear connector. syncRoles reports whether the role
-// resource type will be synced under the current configuration (derived from
-// cli.ConnectorOpts.WillSyncResourceType in main.go); when false, the user
-// syncer skips emitting role grants.
-func New(ctx context.Context, apiKey string, skipProjects bool, ticketSchemaTeamIDs []string, baseURL string, syncRoles bool) (*Linear, error) {
+// New returns the Linear connector.
+func New(ctx context.Context, apiKey string, skipProjects bool, skipPrivateTeams bool, skipAppUsers bool, ticketSchemaTeamIDs []string, baseURL string) (*Linear, error) {
 	client, err := linear.NewClient(ctx, apiKey, baseURL)
 	if err != nil {
 		return nil, err
@@ -104,7 +128,8 @@ func New(ctx context.Context, apiKey string, skipProjects bool, ticketSchemaTeam
 	return &Linear{
 		client:              client,
 		skipProjects:        skipProjects,
+		skipPrivateTeams:    skipPrivateTeams,
+		skipAppUsers:        skipAppUsers,
 		ticketSchemaTeamIDs: ticketSchemaTeamIDs,
-		skipRoleGrants:      !syncRoles,
 	}, nil
 }
@@ -1,13 +1,19 @@
 package connector
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/conductorone/baton-linear/pkg/linear"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // resourcePageSize defines a default page size for pagination.
@@ -19,6 +25,127 @@ func titleCase(s string) string {
 	return titleCaser.String(s)
 }
 
+// entitlementSlug returns the entitlement name, such as "member", falling back
+// to the last segment of the entitlement ID when the slug isn't set.
+func entitlementSlug(entitlement *v2.Entitlement) string {
+	if entitlement.Slug != "" {
+		return entitlement.Slug
+	}
+	parts := strings.Split(entitlement.Id, ":")
+	return parts[len(parts)-1]
+}
+
+// countActiveUsers pages through the workspace users and counts the active
+// ones that match.
+func countActiveUsers(ctx context.Context, client *linear.Client, match func(user *linear.User) bool) (int, error) {
+	var count int
+	var after string
+	for {
+		users, nextToken, _, err := client.GetUsers(ctx, linear.GetResourcesVars{First: resourcePageSize, After: after})
+		if err != nil {
+			return 0, fmt.Errorf("baton-linear: failed to list users: %w", err)
+		}
+		for _, user := range users {
+			userCopy := user
+			if userCopy.Active && match(&userCopy) {
+				count++
+			}
+		}
+		if nextToken == "" {
+			return count, nil
+		}
+		after = nextToken
+	}
+}
+
+// syncedRefs drops the team and creator an integration or webhook points at
+// when they're a private team or an app user that the connector skips, so its
+// profile and grants don't reference resources that aren't synced.
+func syncedRefs(team *linear.Team, creator *linear.User, skipPrivateTeams bool, skipAppUsers bool) (*linear.Team, *linear.User) {
+	if skipPrivateTeams && team != nil && team.Private {
+		team = nil
+	}
+	if skipAppUsers && creator != nil && creator.App {
+		creator = nil
+	}
+	return team, creator
+}
+
+// guardLastAdmin refuses to suspend or demote the workspace's last active
+// admin or owner, which would leave nobody able to administer it.
+func guardLastAdmin(ctx context.Context, client *linear.Client, user *linear.User) error {
+	if !user.Active || (!user.Admin && !user.Owner) {
+		return nil
+	}
+
+	others, err := countActiveUsers(ctx, client, func(u *linear.User) bool {
+		return u.ID != user.ID && (u.Admin || u.Owner)
+	})
+	if err != nil {
+		return err
+	}
+	if others == 0 {
+		return status.Errorf(codes.FailedPrecondition, "baton-linear: %s is the last active admin or owner of the workspace", user.ID)
+	}
+
+	return nil
+}
+
+// keyedMutex hands out one lock per key so read-modify-write updates of the
+// same Linear object are serialized while updates of different objects still
+// run in parallel. Entries are dropped once nobody holds or waits on them.
+//
+// The locks only cover this process. Another connector instance, or someone
+// editing the object in Linear, can still race an update, so callers re-read
+// and verify the object around their write instead of relying on the lock
+// alone.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	sync.Mutex
+	// refs counts the callers holding or waiting on the lock.
+	refs int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: make(map[string]*keyedLock)}
+}
+
+// Lock acquires the lock for key and returns the function that releases it.
+func (k *keyedMutex) Lock(key string) func() {
+	k.mu.Lock()
+	l, ok := k.locks[key]
+	if !ok {
+		l = &keyedLock{}
+		k.locks[key] = l
+	}
+	l.refs++
+	k.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+
+		k.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
+
+// isFirstPage reports whether token asks for the first page of a resource's
+// grants. Single-valued fields such as a project's lead come back with every
+// page of a paginated query, so the grants built from them are only emitted
+// with the first page.
+func isFirstPage(token *pagination.Token) bool {
+	return token == nil || token.Token == ""
+}
+
 func parsePageToken(i string, resourceID *v2.ResourceId) (*pagination.Bag, error) {
 	b := &pagination.Bag{}
 	err := b.Unmarshal(i)
@@ -0,0 +1,170 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-linear/pkg/linear"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+var _ connectorbuilder.ResourceSyncer = (*initiativeResourceType)(nil)
+
+const (
+	initiativeOwner    = "owner"
+	initiativeIncludes = "includes"
+)
+
+type initiativeResourceType struct {
+	resourceType *v2.ResourceType
+	client       *linear.Client
+	// skipProjects drops the includes entitlement, whose grants would point
+	// at project resources that aren't synced.
+	skipProjects bool
+	skipAppUsers bool
+}
+
+func (o *initiativeResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return o.resourceType
+}
+
+// Create a new connector resource for a Linear initiative.
+func initiativeResource(initiative *linear.Initiative, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"initiative_id": initiative.ID,
+		"status":        initiative.Status,
+		"url":           initiative.URL,
+	}
+
+	ret, err := rs.NewResource(
+		initiative.Name,
+		resourceTypeInitiative,
+		initiative.ID,
+		rs.WithDescription(initiative.Description),
+		rs.WithResourceProfile(profile),
+		rs.WithParentResourceID(parentResourceID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (o *initiativeResourceType) List(ctx context.Context, parentId *v2.ResourceId, token *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	var annotations annotations.Annotations
+	bag, err := parsePageToken(token.Token, &v2.ResourceId{ResourceType: resourceTypeInitiative.Id})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	initiatives, nextToken, rlData, err := o.client.GetInitiatives(ctx, linear.GetResourcesVars{First: resourcePageSize, After: bag.PageToken()})
+	annotations.WithRateLimiting(rlData)
+	if err != nil {
+		return nil, "", annotations, fmt.Errorf("linear-connector: failed to list initiatives: %w", err)
+	}
+
+	pageToken, err := bag.NextToken(nextToken)
+	if err != nil {
+		return nil, "", annotations, err
+	}
+
+	var rv []*v2.Resource
+	for _, initiative := range initiatives {
+		initiativeCopy := initiative
+		ir, err := initiativeResource(&initiativeCopy, parentId)
+		if err != nil {
+			return nil, "", annotations, err
+		}
+		rv = append(rv, ir)
+	}
+
+	return rv, pageToken, annotations, nil
+}
+
+func (o *initiativeResourceType) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	var rv []*v2.Entitlement
+
+	ownerOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser),
+		ent.WithDescription(fmt.Sprintf("Owner of %s Linear initiative", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s Initiative %s", resource.DisplayName, initiativeOwner)),
+	}
+	rv = append(rv, ent.NewAssignmentEntitlement(resource, initiativeOwner, ownerOptions...))
+
+	if !o.skipProjects {
+		includesOptions := []ent.EntitlementOption{
+			ent.WithGrantableTo(resourceTypeProject),
+			ent.WithDescription(fmt.Sprintf("Project included in %s Linear initiative", resource.DisplayName)),
+			ent.WithDisplayName(fmt.Sprintf("%s Initiative %s", resource.DisplayName, initiativeIncludes)),
+		}
+		rv = append(rv, ent.NewAssignmentEntitlement(resource, initiativeIncludes, includesOptions...))
+	}
+
+	return rv, "", nil, nil
+}
+
+func (o *initiativeResourceType) Grants(ctx context.Context, resource *v2.Resource, token *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	var annotations annotations.Annotations
+	var rv []*v2.Grant
+
+	bag, err := parsePageToken(token.Token, resource.Id)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	first := resourcePageSize
+	if o.skipProjects {
+		// Only the owner is needed, so don't page through the projects.
+		first = 1
+	}
+
+	initiative, nextToken, rlData, err := o.client.GetInitiative(ctx, linear.GetInitiativeVars{InitiativeId: resource.Id.Resource, After: bag.PageToken(), First: first})
+	annotations.WithRateLimiting(rlData)
+	if err != nil {
+		return nil, "", annotations, err
+	}
+
+	if isFirstPage(token) && initiative.Owner != nil && (!initiative.Owner.App || !o.skipAppUsers) {
+		ur, err := userResource(ctx, initiative.Owner, resource.Id)
+		if err != nil {
+			return nil, "", annotations, err
+		}
+		rv = append(rv, grant.NewGrant(resource, initiativeOwner, ur.Id))
+	}
+
+	if o.skipProjects {
+		return rv, "", annotations, nil
+	}
+
+	pageToken, err := bag.NextToken(nextToken)
+	if err != nil {
+		return nil, "", annotations, err
+	}
+
+	for _, project := range initiative.Projects.Nodes {
+		projectCopy := project
+		pr, err := projectResource(&projectCopy, nil)
+		if err != nil {
+			return nil, "", annotations, err
+		}
+		rv = append(rv, grant.NewGrant(resource, initiativeIncludes, pr.Id))
+	}
+
+	return rv, pageToken, annotations, nil
+}
+
+func initiativeBuilder(client *linear.Client, skipProjects bool, skipAppUsers bool) *initiativeResourceType {
+	return &initiativeResourceType{
+		resourceType: resourceTypeInitiative,
+		client:       client,
+		skipProjects: skipProjects,
+		skipAppUsers: skipAppUsers,
+	}
+}
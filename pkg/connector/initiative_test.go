@@ -0,0 +1,111 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/conductorone/baton-linear/pkg/linear"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+)
+
+func newTestInitiativeBuilder(t *testing.T, skipProjects bool, handler http.HandlerFunc) *initiativeResourceType {
+	return initiativeBuilder(newTestClient(t, handler), skipProjects, false)
+}
+
+// initiativeTestServer serves an initiative owned by u1 whose projects come
+// back in two pages.
+func initiativeTestServer(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = decodeJSON(t, r, &req)
+		w.Header().Set("Content-Type", "application/json")
+		if req.Variables["after"] == "cursor-1" {
+			_, _ = w.Write([]byte(`{"data":{"initiative":{"id":"init-1","owner":{"id":"u1"},"projects":{"nodes":[{"id":"p2"}],"pageInfo":{"hasNextPage":false}}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"initiative":{"id":"init-1","owner":{"id":"u1"},"projects":{"nodes":[{"id":"p1"}],"pageInfo":{"hasNextPage":true,"endCursor":"cursor-1"}}}}}`))
+	}
+}
+
+func testInitiativeResource(t *testing.T) *v2.Resource {
+	t.Helper()
+	ir, err := initiativeResource(&linear.Initiative{ID: "init-1", Name: "Roadmap"}, nil)
+	if err != nil {
+		t.Fatalf("initiativeResource: %v", err)
+	}
+	return ir
+}
+
+func TestInitiativeGrants(t *testing.T) {
+	ib := newTestInitiativeBuilder(t, false, initiativeTestServer(t))
+	ir := testInitiativeResource(t)
+
+	var got []string
+	token := &pagination.Token{}
+	for {
+		grants, next, _, err := ib.Grants(context.Background(), ir, token)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, g := range grants {
+			got = append(got, entitlementSlug(g.Entitlement)+":"+g.Principal.Id.ResourceType+":"+g.Principal.Id.Resource)
+		}
+		if next == "" {
+			break
+		}
+		token = &pagination.Token{Token: next}
+	}
+
+	want := []string{"owner:user:u1", "includes:project:p1", "includes:project:p2"}
+	if len(got) != len(want) {
+		t.Fatalf("grants: want %v got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("grants: want %v got %v", want, got)
+			break
+		}
+	}
+}
+
+func TestInitiative_SkipProjects(t *testing.T) {
+	ib := newTestInitiativeBuilder(t, true, initiativeTestServer(t))
+	ir := testInitiativeResource(t)
+
+	entitlements, _, _, err := ib.Entitlements(context.Background(), ir, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entitlements) != 1 || entitlementSlug(entitlements[0]) != initiativeOwner {
+		t.Errorf("expected only the owner entitlement, got %v", entitlements)
+	}
+
+	grants, next, _, err := ib.Grants(context.Background(), ir, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next != "" {
+		t.Errorf("expected no further pages, got %q", next)
+	}
+	if len(grants) != 1 || grants[0].Principal.Id.Resource != "u1" {
+		t.Errorf("expected only the owner grant, got %v", grants)
+	}
+}
+
+func TestInitiativeGrants_SkipsAppOwner(t *testing.T) {
+	ib := initiativeBuilder(newTestClient(t, serveFixtures(t, map[string]string{
+		"query Initiative(": `{"data":{"initiative":{"id":"init-1","owner":{"id":"bot-1","app":true},"projects":{"nodes":[],"pageInfo":{"hasNextPage":false}}}}}`,
+	})), true, true)
+
+	grants, _, _, err := ib.Grants(context.Background(), testInitiativeResource(t), &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grants) != 0 {
+		t.Errorf("expected no grant to the app owner, got %v", grants)
+	}
+}
@@ -0,0 +1,182 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/conductorone/baton-linear/pkg/linear"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+var (
+	_ connectorbuilder.ResourceSyncer         = (*integrationResourceType)(nil)
+	_ connectorbuilder.ResourceDeleterLimited = (*integrationResourceType)(nil)
+)
+
+const (
+	integrationInstalledBy = "installed_by"
+	integrationScopedTo    = "scoped_to"
+)
+
+type integrationResourceType struct {
+	resourceType     *v2.ResourceType
+	client           *linear.Client
+	skipPrivateTeams bool
+	skipAppUsers     bool
+}
+
+func (o *integrationResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return o.resourceType
+}
+
+// Create a new connector resource for an integration installed in Linear.
+// Integrations scoped to a team carry the team in their name and profile, and
+// the creator is kept in the profile for Grants.
+func integrationResource(integration *linear.Integration, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	name := integration.Service
+	profile := map[string]interface{}{
+		"integration_id": integration.ID,
+		"service":        integration.Service,
+		"created_at":     integration.CreatedAt.Format(time.RFC3339),
+	}
+	if integration.Team != nil {
+		name = fmt.Sprintf("%s (%s)", integration.Service, integration.Team.Key)
+		profile["team_id"] = integration.Team.ID
+		profile["team_name"] = integration.Team.Name
+		profile[teamKeyProfileKey] = integration.Team.Key
+	}
+	if integration.Creator != nil {
+		profile["creator_id"] = integration.Creator.ID
+	}
+
+	ret, err := rs.NewAppResource(
+		name,
+		resourceTypeIntegration,
+		integration.ID,
+		[]rs.AppTraitOption{rs.WithAppProfile(profile)},
+		rs.WithDescription(fmt.Sprintf("%s integration", integration.Service)),
+		rs.WithParentResourceID(parentResourceID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (o *integrationResourceType) List(ctx context.Context, parentId *v2.ResourceId, token *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	var annotations annotations.Annotations
+	if parentId == nil {
+		return nil, "", nil, nil
+	}
+
+	bag, err := parsePageToken(token.Token, &v2.ResourceId{ResourceType: resourceTypeIntegration.Id})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	integrations, nextToken, rlData, err := o.client.GetIntegrations(ctx, linear.GetResourcesVars{First: resourcePageSize, After: bag.PageToken()})
+	annotations.WithRateLimiting(rlData)
+	if err != nil {
+		return nil, "", annotations, fmt.Errorf("linear-connector: failed to list integrations: %w", err)
+	}
+
+	pageToken, err := bag.NextToken(nextToken)
+	if err != nil {
+		return nil, "", annotations, err
+	}
+
+	var rv []*v2.Resource
+	for _, integration := range integrations {
+		integrationCopy := integration
+		integrationCopy.Team, integrationCopy.Creator = syncedRefs(integrationCopy.Team, integrationCopy.Creator, o.skipPrivateTeams, o.skipAppUsers)
+		ir, err := integrationResource(&integrationCopy, parentId)
+		if err != nil {
+			return nil, "", annotations, err
+		}
+		rv = append(rv, ir)
+	}
+
+	return rv, pageToken, annotations, nil
+}
+
+func (o *integrationResourceType) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	installedByOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser),
+		ent.WithDescription(fmt.Sprintf("Installed the %s Linear integration", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s Integration installed by", resource.DisplayName)),
+	}
+
+	scopedToOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeTeam),
+		ent.WithDescription(fmt.Sprintf("Team the %s Linear integration is scoped to", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s Integration scoped to", resource.DisplayName)),
+	}
+
+	return []*v2.Entitlement{
+		ent.NewAssignmentEntitlement(resource, integrationInstalledBy, installedByOptions...),
+		ent.NewAssignmentEntitlement(resource, integrationScopedTo, scopedToOptions...),
+	}, "", nil, nil
+}
+
+// Grants emits the user who installed the integration and the team it is
+// scoped to, both recorded on the resource when it was listed.
+func (o *integrationResourceType) Grants(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	appTrait, err := rs.GetAppTrait(resource)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	profile := appTrait.GetProfile()
+
+	var rv []*v2.Grant
+	// Integrations installed by a since-removed user have no creator.
+	if creatorID, ok := rs.GetProfileStringValue(profile, "creator_id"); ok {
+		principalID, err := rs.NewResourceID(resourceTypeUser, creatorID)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		rv = append(rv, grant.NewGrant(resource, integrationInstalledBy, principalID))
+	}
+
+	if teamID, ok := rs.GetProfileStringValue(profile, "team_id"); ok {
+		principalID, err := rs.NewResourceID(resourceTypeTeam, teamID)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		rv = append(rv, grant.NewGrant(resource, integrationScopedTo, principalID))
+	}
+
+	return rv, "", nil, nil
+}
+
+// Delete uninstalls the integration from the workspace.
+func (o *integrationResourceType) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	if resourceId.GetResourceType() != resourceTypeIntegration.Id {
+		return nil, fmt.Errorf("baton-linear: non-integration resource passed to integration delete: %s", resourceId.GetResourceType())
+	}
+
+	success, err := o.client.DeleteIntegration(ctx, resourceId.GetResource())
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed to delete integration: %w", err)
+	}
+	if !success {
+		return nil, fmt.Errorf("baton-linear: integrationDelete returned success=false")
+	}
+	return nil, nil
+}
+
+func integrationBuilder(client *linear.Client, skipPrivateTeams bool, skipAppUsers bool) *integrationResourceType {
+	return &integrationResourceType{
+		resourceType:     resourceTypeIntegration,
+		client:           client,
+		skipPrivateTeams: skipPrivateTeams,
+		skipAppUsers:     skipAppUsers,
+	}
+}
@@ -0,0 +1,169 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/conductorone/baton-linear/pkg/linear"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+func newTestIntegrationBuilder(t *testing.T, handler http.HandlerFunc) *integrationResourceType {
+	return integrationBuilder(newTestClient(t, handler), false, false)
+}
+
+func TestIntegrationList_TeamScope(t *testing.T) {
+	ib := newTestIntegrationBuilder(t, serveFixtures(t, map[string]string{
+		"query Integrations(": `{"data":{"integrations":{"nodes":[
+			{"id":"int-1","service":"slack","createdAt":"2024-01-01T00:00:00Z"},
+			{"id":"int-2","service":"github","createdAt":"2024-01-01T00:00:00Z","team":{"id":"team-1","name":"Engineering","key":"ENG"}}
+		],"pageInfo":{"hasNextPage":false}}}}`,
+	}))
+
+	orgID := &v2.ResourceId{ResourceType: resourceTypeOrg.Id, Resource: "org-1"}
+	resources, _, _, err := ib.List(context.Background(), orgID, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 integrations, got %d", len(resources))
+	}
+	if resources[0].DisplayName != "slack" || resources[1].DisplayName != "github (ENG)" {
+		t.Errorf("unexpected names: %q, %q", resources[0].DisplayName, resources[1].DisplayName)
+	}
+
+	appTrait, err := rs.GetAppTrait(resources[1])
+	if err != nil {
+		t.Fatalf("expected app trait: %v", err)
+	}
+	if got := appTrait.GetProfile().GetFields()["team_id"].GetStringValue(); got != "team-1" {
+		t.Errorf("expected team-1 scope, got %q", got)
+	}
+}
+
+func TestIntegrationGrants(t *testing.T) {
+	ib := newTestIntegrationBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("API should not be called for integration grants")
+	})
+
+	integration, err := integrationResource(&linear.Integration{
+		ID:      "int-1",
+		Service: "github",
+		Creator: &linear.User{ID: "u1", Name: "Ada"},
+		Team:    &linear.Team{ID: "team-1", Name: "Engineering", Key: "ENG"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("integrationResource: %v", err)
+	}
+
+	grants, _, _, err := ib.Grants(context.Background(), integration, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grants) != 2 {
+		t.Fatalf("expected installed_by and scoped_to grants, got %v", grants)
+	}
+	if grants[0].Principal.Id.Resource != "u1" || !strings.HasSuffix(grants[0].Entitlement.Id, ":"+integrationInstalledBy) {
+		t.Errorf("expected an installed_by grant to u1, got %v", grants[0])
+	}
+	if grants[1].Principal.Id.ResourceType != resourceTypeTeam.Id || grants[1].Principal.Id.Resource != "team-1" ||
+		!strings.HasSuffix(grants[1].Entitlement.Id, ":"+integrationScopedTo) {
+		t.Errorf("expected a scoped_to grant to team-1, got %v", grants[1])
+	}
+}
+
+func TestIntegrationGrants_WorkspaceWideWithoutCreator(t *testing.T) {
+	ib := newTestIntegrationBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("API should not be called for integration grants")
+	})
+
+	integration, err := integrationResource(&linear.Integration{ID: "int-1", Service: "slack"}, nil)
+	if err != nil {
+		t.Fatalf("integrationResource: %v", err)
+	}
+
+	grants, _, _, err := ib.Grants(context.Background(), integration, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grants) != 0 {
+		t.Errorf("expected no grants, got %v", grants)
+	}
+}
+
+func TestIntegrationDelete(t *testing.T) {
+	var seenID string
+	ib := newTestIntegrationBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = decodeJSON(t, r, &req)
+		if !strings.Contains(req["query"].(string), "integrationDelete(") {
+			t.Errorf("unexpected query: %s", req["query"])
+		}
+		seenID = req["variables"].(map[string]interface{})["id"].(string)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"integrationDelete":{"success":true}}}`))
+	})
+
+	_, err := ib.Delete(context.Background(), &v2.ResourceId{ResourceType: resourceTypeIntegration.Id, Resource: "int-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seenID != "int-1" {
+		t.Errorf("expected int-1 to be deleted, got %q", seenID)
+	}
+}
+
+func TestIntegrationList_SkipsPrivateTeamScope(t *testing.T) {
+	ib := newTestIntegrationBuilder(t, serveFixtures(t, map[string]string{
+		"query Integrations(": `{"data":{"integrations":{"nodes":[
+			{"id":"int-1","service":"github","createdAt":"2024-01-01T00:00:00Z","team":{"id":"team-2","name":"Security","key":"SEC","private":true}}
+		],"pageInfo":{"hasNextPage":false}}}}`,
+	}))
+	ib.skipPrivateTeams = true
+
+	orgID := &v2.ResourceId{ResourceType: resourceTypeOrg.Id, Resource: "org-1"}
+	resources, _, _, err := ib.List(context.Background(), orgID, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 1 || resources[0].DisplayName != "github" {
+		t.Fatalf("expected the integration without its private team, got %v", resources)
+	}
+	appTrait, err := rs.GetAppTrait(resources[0])
+	if err != nil {
+		t.Fatalf("expected app trait: %v", err)
+	}
+	if _, ok := appTrait.GetProfile().GetFields()["team_id"]; ok {
+		t.Error("expected no reference to the private team")
+	}
+}
+
+func TestIntegrationList_SkipsAppCreator(t *testing.T) {
+	ib := newTestIntegrationBuilder(t, serveFixtures(t, map[string]string{
+		"query Integrations(": `{"data":{"integrations":{"nodes":[
+			{"id":"int-1","service":"github","createdAt":"2024-01-01T00:00:00Z","creator":{"id":"bot-1","app":true}}
+		],"pageInfo":{"hasNextPage":false}}}}`,
+	}))
+	ib.skipAppUsers = true
+
+	orgID := &v2.ResourceId{ResourceType: resourceTypeOrg.Id, Resource: "org-1"}
+	resources, _, _, err := ib.List(context.Background(), orgID, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("expected the integration to be listed, got %v", resources)
+	}
+
+	grants, _, _, err := ib.Grants(context.Background(), resources[0], &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grants) != 0 {
+		t.Errorf("expected no grant to the app creator, got %v", grants)
+	}
+}
@@ -0,0 +1,138 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/conductorone/baton-linear/pkg/linear"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	resource "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+var (
+	_ connectorbuilder.ResourceSyncer         = (*inviteResourceType)(nil)
+	_ connectorbuilder.ResourceDeleterLimited = (*inviteResourceType)(nil)
+)
+
+type inviteResourceType struct {
+	resourceType *v2.ResourceType
+	client       *linear.Client
+}
+
+func (o *inviteResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return o.resourceType
+}
+
+// Create a new connector resource for a pending Linear workspace invite.
+// Expired invites are still listed, but marked disabled, so they can be
+// cleaned up.
+func inviteResource(invite *linear.OrganizationInvite, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"email":         invite.Email,
+		"role":          invite.Role,
+		"inviter_id":    invite.Inviter.ID,
+		"inviter_name":  invite.Inviter.Name,
+		"inviter_email": invite.Inviter.Email,
+		"created_at":    invite.CreatedAt.Format(time.RFC3339),
+	}
+
+	status := v2.Status_RESOURCE_STATUS_ENABLED
+	var statusDetails string
+	if invite.ExpiresAt != nil {
+		profile["expires_at"] = invite.ExpiresAt.Format(time.RFC3339)
+		if invite.ExpiresAt.Before(time.Now()) {
+			status = v2.Status_RESOURCE_STATUS_DISABLED
+			statusDetails = "expired"
+		}
+	}
+
+	ret, err := resource.NewResource(
+		invite.Email,
+		resourceTypeInvite,
+		invite.ID,
+		resource.WithDescription(fmt.Sprintf("Invite for %s to join as %s", invite.Email, invite.Role)),
+		resource.WithResourceProfile(profile),
+		resource.WithResourceStatus(status, statusDetails),
+		resource.WithParentResourceID(parentResourceID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (o *inviteResourceType) List(ctx context.Context, parentId *v2.ResourceId, token *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	var annotations annotations.Annotations
+	if parentId == nil {
+		return nil, "", nil, nil
+	}
+
+	bag, err := parsePageToken(token.Token, &v2.ResourceId{ResourceType: resourceTypeInvite.Id})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	invites, nextToken, rlData, err := o.client.GetOrganizationInvites(ctx, linear.GetResourcesVars{First: resourcePageSize, After: bag.PageToken()})
+	annotations.WithRateLimiting(rlData)
+	if err != nil {
+		return nil, "", annotations, fmt.Errorf("linear-connector: failed to list invites: %w", err)
+	}
+
+	pageToken, err := bag.NextToken(nextToken)
+	if err != nil {
+		return nil, "", annotations, err
+	}
+
+	var rv []*v2.Resource
+	for _, invite := range invites {
+		// Accepted invites have become users and are synced as such.
+		if invite.AcceptedAt != nil {
+			continue
+		}
+
+		inviteCopy := invite
+		ir, err := inviteResource(&inviteCopy, parentId)
+		if err != nil {
+			return nil, "", annotations, err
+		}
+		rv = append(rv, ir)
+	}
+
+	return rv, pageToken, annotations, nil
+}
+
+func (o *inviteResourceType) Entitlements(_ context.Context, _ *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+func (o *inviteResourceType) Grants(_ context.Context, _ *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+// Delete cancels a pending invite so it can no longer be accepted.
+func (o *inviteResourceType) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	if resourceId.GetResourceType() != resourceTypeInvite.Id {
+		return nil, fmt.Errorf("baton-linear: non-invite resource passed to invite delete: %s", resourceId.GetResourceType())
+	}
+
+	success, err := o.client.DeleteOrganizationInvite(ctx, resourceId.GetResource())
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed to delete invite: %w", err)
+	}
+	if !success {
+		return nil, fmt.Errorf("baton-linear: organizationInviteDelete returned success=false")
+	}
+	return nil, nil
+}
+
+func inviteBuilder(client *linear.Client) *inviteResourceType {
+	return &inviteResourceType{
+		resourceType: resourceTypeInvite,
+		client:       client,
+	}
+}
@@ -0,0 +1,76 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+)
+
+func newTestInviteBuilder(t *testing.T, handler http.HandlerFunc) *inviteResourceType {
+	return inviteBuilder(newTestClient(t, handler))
+}
+
+func TestInviteList_SkipsAccepted(t *testing.T) {
+	ib := newTestInviteBuilder(t, serveFixtures(t, map[string]string{
+		"query OrganizationInvites(": `{"data":{"organizationInvites":{"nodes":[
+			{"id":"inv-1","email":"pending@example.com","role":"user","createdAt":"2024-01-01T00:00:00Z","expiresAt":"2999-01-01T00:00:00Z","inviter":{"id":"u1","name":"Ada"}},
+			{"id":"inv-2","email":"joined@example.com","role":"user","createdAt":"2024-01-01T00:00:00Z","acceptedAt":"2024-01-02T00:00:00Z"},
+			{"id":"inv-3","email":"stale@example.com","role":"admin","createdAt":"2024-01-01T00:00:00Z","expiresAt":"2024-01-08T00:00:00Z"}
+		],"pageInfo":{"hasNextPage":false}}}}`,
+	}))
+
+	orgID := &v2.ResourceId{ResourceType: resourceTypeOrg.Id, Resource: "org-1"}
+	resources, _, _, err := ib.List(context.Background(), orgID, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 outstanding invites, got %d", len(resources))
+	}
+	if resources[0].Id.Resource != "inv-1" || resources[1].Id.Resource != "inv-3" {
+		t.Errorf("unexpected invites: %s, %s", resources[0].Id.Resource, resources[1].Id.Resource)
+	}
+	if got := resources[0].GetStatus().GetStatus(); got != v2.Status_RESOURCE_STATUS_ENABLED {
+		t.Errorf("pending invite: expected enabled status, got %v", got)
+	}
+	if got := resources[1].GetStatus().GetStatus(); got != v2.Status_RESOURCE_STATUS_DISABLED {
+		t.Errorf("expired invite: expected disabled status, got %v", got)
+	}
+}
+
+func TestInviteDelete(t *testing.T) {
+	var seenID string
+	ib := newTestInviteBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = decodeJSON(t, r, &req)
+		if !strings.Contains(req["query"].(string), "organizationInviteDelete(") {
+			t.Errorf("unexpected query: %s", req["query"])
+		}
+		seenID = req["variables"].(map[string]interface{})["id"].(string)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"organizationInviteDelete":{"success":true}}}`))
+	})
+
+	_, err := ib.Delete(context.Background(), &v2.ResourceId{ResourceType: resourceTypeInvite.Id, Resource: "inv-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seenID != "inv-1" {
+		t.Errorf("expected invite inv-1 to be deleted, got %q", seenID)
+	}
+}
+
+func TestInviteDelete_WrongResourceType(t *testing.T) {
+	ib := newTestInviteBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("API should not be called for a non-invite resource")
+	})
+
+	_, err := ib.Delete(context.Background(), &v2.ResourceId{ResourceType: resourceTypeUser.Id, Resource: "u1"})
+	if err == nil {
+		t.Fatal("expected error for non-invite resource")
+	}
+}
@@ -0,0 +1,173 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/conductorone/baton-linear/pkg/linear"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+var (
+	_ connectorbuilder.ResourceSyncer         = (*oauthAppResourceType)(nil)
+	_ connectorbuilder.ResourceDeleterLimited = (*oauthAppResourceType)(nil)
+)
+
+const (
+	oauthAppAuthorized = "authorized"
+
+	oauthAppMembershipsProfileKey = "memberships"
+)
+
+type oauthAppResourceType struct {
+	resourceType *v2.ResourceType
+	client       *linear.Client
+}
+
+func (o *oauthAppResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return o.resourceType
+}
+
+// Create a new connector resource for an OAuth application authorized in the
+// Linear workspace.
+func oauthAppResource(app *linear.AuthorizedApplication, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"app_id":        app.AppID,
+		"client_id":     app.ClientID,
+		"developer":     app.Developer,
+		"developer_url": app.DeveloperURL,
+		"scopes":        strings.Join(app.Scope, ","),
+		"authorized_by": int64(len(app.Memberships)),
+	}
+
+	// Linear only lists applications as a whole, so the authorizations are
+	// kept on the resource for Grants rather than fetched again per app.
+	memberships := make([]interface{}, 0, len(app.Memberships))
+	for _, membership := range app.Memberships {
+		m := map[string]interface{}{
+			"user_id":       membership.UserID,
+			"authorized_at": membership.CreatedAt.Format(time.RFC3339),
+		}
+		if membership.LastActiveAt != nil {
+			m["last_active_at"] = membership.LastActiveAt.Format(time.RFC3339)
+		}
+		memberships = append(memberships, m)
+	}
+	profile[oauthAppMembershipsProfileKey] = memberships
+
+	appTraitOptions := []rs.AppTraitOption{
+		rs.WithAppProfile(profile),
+	}
+	if app.DeveloperURL != "" {
+		appTraitOptions = append(appTraitOptions, rs.WithAppHelpURL(app.DeveloperURL))
+	}
+
+	ret, err := rs.NewAppResource(
+		app.Name,
+		resourceTypeOAuthApp,
+		app.AppID,
+		appTraitOptions,
+		rs.WithDescription(app.Description),
+		rs.WithParentResourceID(parentResourceID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (o *oauthAppResourceType) List(ctx context.Context, parentId *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	var annotations annotations.Annotations
+	if parentId == nil {
+		return nil, "", nil, nil
+	}
+
+	apps, rlData, err := o.client.GetAuthorizedApplications(ctx)
+	annotations.WithRateLimiting(rlData)
+	if err != nil {
+		return nil, "", annotations, fmt.Errorf("linear-connector: failed to list authorized applications: %w", err)
+	}
+
+	var rv []*v2.Resource
+	for _, app := range apps {
+		appCopy := app
+		ar, err := oauthAppResource(&appCopy, parentId)
+		if err != nil {
+			return nil, "", annotations, err
+		}
+		rv = append(rv, ar)
+	}
+
+	return rv, "", annotations, nil
+}
+
+func (o *oauthAppResourceType) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	options := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser),
+		ent.WithDescription(fmt.Sprintf("Authorized %s OAuth application", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s Application %s", resource.DisplayName, oauthAppAuthorized)),
+	}
+
+	return []*v2.Entitlement{ent.NewAssignmentEntitlement(resource, oauthAppAuthorized, options...)}, "", nil, nil
+}
+
+// Grants lists the users who authorized the application, as recorded on the
+// resource when it was listed.
+func (o *oauthAppResourceType) Grants(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	appTrait, err := rs.GetAppTrait(resource)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	var rv []*v2.Grant
+	for _, value := range appTrait.GetProfile().GetFields()[oauthAppMembershipsProfileKey].GetListValue().GetValues() {
+		membership := value.GetStructValue().GetFields()
+
+		principalID, err := rs.NewResourceID(resourceTypeUser, membership["user_id"].GetStringValue())
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		metadata := map[string]interface{}{
+			"authorized_at": membership["authorized_at"].GetStringValue(),
+		}
+		if lastActiveAt, ok := membership["last_active_at"]; ok {
+			metadata["last_active_at"] = lastActiveAt.GetStringValue()
+		}
+		rv = append(rv, grant.NewGrant(resource, oauthAppAuthorized, principalID, grant.WithGrantMetadata(metadata)))
+	}
+
+	return rv, "", nil, nil
+}
+
+// Delete revokes the application's authorization for the whole workspace.
+func (o *oauthAppResourceType) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	if resourceId.GetResourceType() != resourceTypeOAuthApp.Id {
+		return nil, fmt.Errorf("baton-linear: non-oauth-app resource passed to oauth app delete: %s", resourceId.GetResourceType())
+	}
+
+	success, err := o.client.RevokeAuthorizedApplication(ctx, resourceId.GetResource())
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed to revoke application authorization: %w", err)
+	}
+	if !success {
+		return nil, fmt.Errorf("baton-linear: workspaceAuthorizedApplicationRevoke returned success=false")
+	}
+	return nil, nil
+}
+
+func oauthAppBuilder(client *linear.Client) *oauthAppResourceType {
+	return &oauthAppResourceType{
+		resourceType: resourceTypeOAuthApp,
+		client:       client,
+	}
+}
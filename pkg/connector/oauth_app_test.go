@@ -0,0 +1,105 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+const testAuthorizedApplications = `{"data":{"workspaceAuthorizedApplications":[
+	{"appId":"app-1","name":"Zapier","developer":"Zapier Inc","developerUrl":"https://zapier.com","scope":["read","write"],"memberships":[
+		{"userId":"u1","createdAt":"2024-01-01T00:00:00Z","lastActiveAt":"2024-02-01T00:00:00Z"},
+		{"userId":"u2","createdAt":"2024-01-03T00:00:00Z"}
+	]},
+	{"appId":"app-2","name":"Figma","scope":["read"],"memberships":[{"userId":"u3","createdAt":"2024-01-01T00:00:00Z"}]}
+]}}`
+
+func newTestOAuthAppBuilder(t *testing.T, handler http.HandlerFunc) *oauthAppResourceType {
+	return oauthAppBuilder(newTestClient(t, handler))
+}
+
+func serveAuthorizedApplications(t *testing.T) http.HandlerFunc {
+	return serveFixtures(t, map[string]string{"query WorkspaceAuthorizedApplications": testAuthorizedApplications})
+}
+
+func TestOAuthAppList(t *testing.T) {
+	ob := newTestOAuthAppBuilder(t, serveAuthorizedApplications(t))
+
+	orgID := &v2.ResourceId{ResourceType: resourceTypeOrg.Id, Resource: "org-1"}
+	resources, _, _, err := ob.List(context.Background(), orgID, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 applications, got %d", len(resources))
+	}
+
+	appTrait, err := rs.GetAppTrait(resources[0])
+	if err != nil {
+		t.Fatalf("expected app trait: %v", err)
+	}
+	if got := appTrait.GetProfile().GetFields()["scopes"].GetStringValue(); got != "read,write" {
+		t.Errorf("expected scopes read,write, got %q", got)
+	}
+	if appTrait.GetHelpUrl() != "https://zapier.com" {
+		t.Errorf("expected developer URL as help URL, got %q", appTrait.GetHelpUrl())
+	}
+}
+
+func TestOAuthAppGrants_AuthorizingUsers(t *testing.T) {
+	var calls int
+	serve := serveAuthorizedApplications(t)
+	ob := newTestOAuthAppBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		serve(w, r)
+	})
+
+	orgID := &v2.ResourceId{ResourceType: resourceTypeOrg.Id, Resource: "org-1"}
+	resources, _, _, err := ob.List(context.Background(), orgID, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	grants, _, _, err := ob.Grants(context.Background(), resources[0], &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grants) != 2 {
+		t.Fatalf("expected 2 grants, got %d", len(grants))
+	}
+	for i, want := range []string{"u1", "u2"} {
+		if got := grants[i].Principal.Id; got.Resource != want || got.ResourceType != resourceTypeUser.Id {
+			t.Errorf("grant %d: expected user %s, got %v", i, want, got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected the applications to be fetched once, got %d requests", calls)
+	}
+}
+
+func TestOAuthAppDelete(t *testing.T) {
+	var seenID string
+	ob := newTestOAuthAppBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = decodeJSON(t, r, &req)
+		if !strings.Contains(req["query"].(string), "workspaceAuthorizedApplicationRevoke(") {
+			t.Errorf("unexpected query: %s", req["query"])
+		}
+		seenID = req["variables"].(map[string]interface{})["appId"].(string)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"workspaceAuthorizedApplicationRevoke":{"success":true}}}`))
+	})
+
+	_, err := ob.Delete(context.Background(), &v2.ResourceId{ResourceType: resourceTypeOAuthApp.Id, Resource: "app-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seenID != "app-1" {
+		t.Errorf("expected app-1 to be revoked, got %q", seenID)
+	}
+}
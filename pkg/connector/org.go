@@ -13,13 +13,20 @@ import (
 	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
 	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
 	resource "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
 )
 
-var _ connectorbuilder.ResourceSyncer = (*orgResourceType)(nil)
+var (
+	_ connectorbuilder.ResourceSyncer      = (*orgResourceType)(nil)
+	_ connectorbuilder.ResourceProvisioner = (*orgResourceType)(nil)
+)
 
 type orgResourceType struct {
-	resourceType *v2.ResourceType
-	client       *linear.Client
+	resourceType     *v2.ResourceType
+	client           *linear.Client
+	skipPrivateTeams bool
+	skipAppUsers     bool
 }
 
 func (o *orgResourceType) ResourceType(_ context.Context) *v2.ResourceType {
@@ -32,7 +39,11 @@ func orgResource(org *linear.Organization, parentResourceID *v2.ResourceId) (*v2
 		resource.WithAnnotation(
 			&v2.ChildResourceType{ResourceTypeId: resourceTypeUser.Id},
 			&v2.ChildResourceType{ResourceTypeId: resourceTypeTeam.Id},
-			&v2.ChildResourceType{ResourceTypeId: resourceTypeRole.Id}),
+			&v2.ChildResourceType{ResourceTypeId: resourceTypeRole.Id},
+			&v2.ChildResourceType{ResourceTypeId: resourceTypeInvite.Id},
+			&v2.ChildResourceType{ResourceTypeId: resourceTypeOAuthApp.Id},
+			&v2.ChildResourceType{ResourceTypeId: resourceTypeIntegration.Id},
+			&v2.ChildResourceType{ResourceTypeId: resourceTypeWebhook.Id}),
 		resource.WithParentResourceID(parentResourceID)}
 
 	orgResource, err := resource.NewResource(
@@ -135,6 +146,10 @@ func (o *orgResourceType) Grants(ctx context.Context, resource *v2.Resource, tok
 	}
 
 	for _, user := range org.Users.Nodes {
+		if user.App && o.skipAppUsers {
+			continue
+		}
+
 		userCopy := user
 		ur, err := userResource(ctx, &userCopy, resource.Id)
 		if err != nil {
@@ -146,6 +161,10 @@ func (o *orgResourceType) Grants(ctx context.Context, resource *v2.Resource, tok
 	}
 
 	for _, team := range org.Teams.Nodes {
+		if team.Private && o.skipPrivateTeams {
+			continue
+		}
+
 		teamCopy := team
 		tr, err := teamResource(&teamCopy, resource.Id)
 		if err != nil {
@@ -159,9 +178,105 @@ func (o *orgResourceType) Grants(ctx context.Context, resource *v2.Resource, tok
 	return rv, pageToken, nil, nil
 }
 
-func orgBuilder(client *linear.Client) *orgResourceType {
+// Grant brings a user into the workspace. Users that already belong to the
+// workspace are left alone, suspended users are reactivated and anyone else
+// is invited by email.
+func (o *orgResourceType) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	if principal.Id.ResourceType != resourceTypeUser.Id {
+		l.Warn(
+			"baton-linear: only users can be granted org membership",
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+		return nil, fmt.Errorf("baton-linear: only users can be granted org membership, teams always belong to the org")
+	}
+
+	email, err := o.principalEmail(ctx, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	user, _, err := o.client.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed to look up user by email: %w", err)
+	}
+	if user != nil && user.Active {
+		return annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+
+	if _, err := inviteUser(ctx, o.client, user, email, "", nil); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// Revoke removes a user from the workspace by suspending them.
+func (o *orgResourceType) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	principal := grant.Principal
+	if principal.Id.ResourceType != resourceTypeUser.Id {
+		l.Warn(
+			"baton-linear: only users can have org membership revoked",
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+		return nil, fmt.Errorf("baton-linear: only users can have org membership revoked, teams always belong to the org")
+	}
+
+	user, _, err := o.client.GetUser(ctx, principal.Id.Resource)
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed to get user: %w", err)
+	}
+	if !user.Active {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+	if err := guardLastAdmin(ctx, o.client, &user); err != nil {
+		return nil, err
+	}
+
+	success, err := o.client.SuspendUser(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed to suspend user: %w", err)
+	}
+	if !success {
+		return nil, fmt.Errorf("baton-linear: userSuspend returned success=false")
+	}
+
+	return nil, nil
+}
+
+// principalEmail returns the email to invite for a user principal, taken from
+// its user trait or, failing that, from Linear.
+func (o *orgResourceType) principalEmail(ctx context.Context, principal *v2.Resource) (string, error) {
+	userTrait, err := resource.GetUserTrait(principal)
+	if err == nil {
+		for _, email := range userTrait.GetEmails() {
+			if email.GetAddress() != "" {
+				return email.GetAddress(), nil
+			}
+		}
+	}
+
+	user, _, err := o.client.GetUser(ctx, principal.Id.Resource)
+	if err != nil {
+		return "", fmt.Errorf("baton-linear: failed to get user: %w", err)
+	}
+	if user.Email == "" {
+		return "", fmt.Errorf("baton-linear: user %s has no email to invite", principal.Id.Resource)
+	}
+
+	return user.Email, nil
+}
+
+func orgBuilder(client *linear.Client, skipPrivateTeams bool, skipAppUsers bool) *orgResourceType {
 	return &orgResourceType{
-		resourceType: resourceTypeOrg,
-		client:       client,
+		resourceType:     resourceTypeOrg,
+		client:           client,
+		skipPrivateTeams: skipPrivateTeams,
+		skipAppUsers:     skipAppUsers,
 	}
 }
@@ -0,0 +1,115 @@
+package connector
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/conductorone/baton-linear/pkg/linear"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	sdkResource "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+func newTestOrgBuilder(t *testing.T, server *fakeInviteServer) *orgResourceType {
+	return orgBuilder(newTestClient(t, server), false, false)
+}
+
+func testOrgResource(t *testing.T) *v2.Resource {
+	t.Helper()
+	or, err := orgResource(&linear.Organization{ID: "org-1", Name: "Org"}, nil)
+	if err != nil {
+		t.Fatalf("orgResource: %v", err)
+	}
+	return or
+}
+
+func testUserPrincipalWithEmail(t *testing.T, id string, email string) *v2.Resource {
+	t.Helper()
+	ur, err := sdkResource.NewUserResource(id, resourceTypeUser, id, []sdkResource.UserTraitOption{sdkResource.WithEmail(email, true)})
+	if err != nil {
+		t.Fatalf("NewUserResource: %v", err)
+	}
+	return ur
+}
+
+func TestOrgGrant(t *testing.T) {
+	tests := []struct {
+		name       string
+		users      string
+		wantCalls  string
+		wantExists bool
+	}{
+		{name: "active user", users: `[{"id":"u1","email":"x@example.com","active":true}]`, wantExists: true},
+		{name: "suspended user", users: `[{"id":"u1","email":"x@example.com","active":false}]`, wantCalls: "userUnsuspend"},
+		{name: "unknown email", wantCalls: "organizationInviteCreate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &fakeInviteServer{t: t, users: tt.users}
+			ob := newTestOrgBuilder(t, server)
+			en := &v2.Entitlement{Resource: testOrgResource(t), Slug: membership}
+
+			annos, err := ob.Grant(context.Background(), testUserPrincipalWithEmail(t, "u1", "x@example.com"), en)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := annos.Contains(&v2.GrantAlreadyExists{}); got != tt.wantExists {
+				t.Errorf("GrantAlreadyExists: want %v got %v", tt.wantExists, got)
+			}
+			if got := strings.Join(server.calls, ","); got != tt.wantCalls {
+				t.Errorf("calls: want %q got %q", tt.wantCalls, got)
+			}
+			if server.lookups != 1 {
+				t.Errorf("expected the user to be looked up once, got %d lookups", server.lookups)
+			}
+			if tt.wantCalls == "organizationInviteCreate" && server.input["email"] != "x@example.com" {
+				t.Errorf("expected invite to x@example.com, got %v", server.input["email"])
+			}
+		})
+	}
+}
+
+func TestOrgGrant_TeamRejected(t *testing.T) {
+	server := &fakeInviteServer{t: t}
+	ob := newTestOrgBuilder(t, server)
+	en := &v2.Entitlement{Resource: testOrgResource(t), Slug: membership}
+
+	_, err := ob.Grant(context.Background(), testTeamPrincipal("team-1"), en)
+	if err == nil || !strings.Contains(err.Error(), "only users") {
+		t.Fatalf("expected only users error, got %v", err)
+	}
+	if len(server.calls) != 0 {
+		t.Errorf("expected no mutations, got %v", server.calls)
+	}
+}
+
+func TestOrgRevoke(t *testing.T) {
+	tests := []struct {
+		name        string
+		user        string
+		wantCalls   string
+		wantRevoked bool
+	}{
+		{name: "active user suspended", user: `{"id":"u1","active":true}`, wantCalls: "userSuspend"},
+		{name: "already suspended", user: `{"id":"u1","active":false}`, wantRevoked: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &fakeInviteServer{t: t, user: tt.user}
+			ob := newTestOrgBuilder(t, server)
+			g := grant.NewGrant(testOrgResource(t), membership, testUserPrincipal("u1").Id)
+
+			annos, err := ob.Revoke(context.Background(), g)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := annos.Contains(&v2.GrantAlreadyRevoked{}); got != tt.wantRevoked {
+				t.Errorf("GrantAlreadyRevoked: want %v got %v", tt.wantRevoked, got)
+			}
+			if got := strings.Join(server.calls, ","); got != tt.wantCalls {
+				t.Errorf("calls: want %q got %q", tt.wantCalls, got)
+			}
+		})
+	}
+}
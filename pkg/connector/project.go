@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/conductorone/baton-linear/pkg/linear"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
@@ -13,18 +14,31 @@ import (
 	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
 	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
 	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
 )
 
-var _ connectorbuilder.ResourceSyncer = (*projectResourceType)(nil)
+var (
+	_ connectorbuilder.ResourceSyncer      = (*projectResourceType)(nil)
+	_ connectorbuilder.ResourceProvisioner = (*projectResourceType)(nil)
+)
 
 const (
 	associated = "associated"
 	membership = "member"
+	lead       = "lead"
 )
 
 type projectResourceType struct {
-	resourceType *v2.ResourceType
-	client       *linear.Client
+	resourceType     *v2.ResourceType
+	client           *linear.Client
+	skipPrivateTeams bool
+	skipAppUsers     bool
+	// locks serializes member and team list updates per project.
+	// projectUpdate replaces the whole list, so two concurrent grants on the
+	// same project would otherwise each drop the other's entry. The lock only
+	// covers this process, so updates are also checked after they're written.
+	locks *keyedMutex
 }
 
 func (o *projectResourceType) ResourceType(_ context.Context) *v2.ResourceType {
@@ -107,6 +121,14 @@ func (o *projectResourceType) Entitlements(_ context.Context, resource *v2.Resou
 	associatedEn := ent.NewAssignmentEntitlement(resource, associated, associatedOptions...)
 	rv = append(rv, associatedEn)
 
+	leadOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser),
+		ent.WithDescription(fmt.Sprintf("Lead of %s Linear project", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s Project %s", resource.DisplayName, lead)),
+	}
+
+	rv = append(rv, ent.NewAssignmentEntitlement(resource, lead, leadOptions...))
+
 	return rv, "", nil, nil
 }
 
@@ -154,7 +176,19 @@ func (o *projectResourceType) Grants(ctx context.Context, resource *v2.Resource,
 	}
 
 	var rv []*v2.Grant
+	if isFirstPage(token) && project.Lead != nil && (!project.Lead.App || !o.skipAppUsers) {
+		ur, err := userResource(ctx, project.Lead, resource.Id)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		rv = append(rv, grant.NewGrant(resource, lead, ur.Id))
+	}
+
 	for _, member := range project.Members.Nodes {
+		if member.App && o.skipAppUsers {
+			continue
+		}
+
 		memberCopy := member
 		ur, err := userResource(ctx, &memberCopy, resource.Id)
 		if err != nil {
@@ -167,6 +201,9 @@ func (o *projectResourceType) Grants(ctx context.Context, resource *v2.Resource,
 
 	for _, team := range project.Teams.Nodes {
 		teamCopy := team
+		if o.skipPrivateTeams && teamCopy.Private {
+			continue
+		}
 		tr, err := teamResource(&teamCopy, resource.Id)
 		if err != nil {
 			return nil, "", nil, err
@@ -178,9 +215,256 @@ func (o *projectResourceType) Grants(ctx context.Context, resource *v2.Resource,
 	return rv, pageToken, nil, nil
 }
 
-func projectBuilder(client *linear.Client) *projectResourceType {
+// projectIDList is one of the ID lists on a project that projectUpdate
+// replaces wholesale, such as its members or its teams.
+type projectIDList struct {
+	// principalType is the resource type whose IDs the list holds.
+	principalType *v2.ResourceType
+	list          func(ctx context.Context, projectID string) ([]string, error)
+	update        func(ctx context.Context, projectID string, ids []string) (bool, error)
+	// required is set when Linear refuses to leave the list empty.
+	required bool
+}
+
+// verify re-reads the list after an update and fails when id isn't where the
+// update put it, which happens when a writer outside this process replaced
+// the list at the same time.
+func (p *projectIDList) verify(ctx context.Context, projectID string, id string, want bool) error {
+	current, err := p.list(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(current, id) != want {
+		return fmt.Errorf("baton-linear: project %s list was changed concurrently, %s %s was not updated", projectID, p.principalType.Id, id)
+	}
+	return nil
+}
+
+// idList returns the project ID list an entitlement is backed by.
+func (o *projectResourceType) idList(entitlement *v2.Entitlement) (*projectIDList, error) {
+	switch slug := entitlementSlug(entitlement); slug {
+	case membership:
+		return &projectIDList{
+			principalType: resourceTypeUser,
+			list:          o.listMemberIDs,
+			update:        o.client.UpdateProjectMembers,
+		}, nil
+	case associated:
+		return &projectIDList{
+			principalType: resourceTypeTeam,
+			list:          o.listTeamIDs,
+			update:        o.client.UpdateProjectTeams,
+			required:      true,
+		}, nil
+	default:
+		return nil, fmt.Errorf("baton-linear: unknown project entitlement %s", slug)
+	}
+}
+
+func (o *projectResourceType) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	if entitlementSlug(entitlement) == lead {
+		return o.grantLead(ctx, principal, entitlement)
+	}
+
+	ids, err := o.idList(entitlement)
+	if err != nil {
+		return nil, err
+	}
+
+	if principal.Id.ResourceType != ids.principalType.Id {
+		l.Warn(
+			"baton-linear: principal type can't be granted this project entitlement",
+			zap.String("entitlement", entitlement.Id),
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+		return nil, fmt.Errorf("baton-linear: only %ss can be granted %s", ids.principalType.Id, entitlementSlug(entitlement))
+	}
+
+	projectID := entitlement.Resource.Id.Resource
+	unlock := o.locks.Lock(projectID)
+	defer unlock()
+
+	current, err := ids.list(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(current, principal.Id.Resource) {
+		return annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+
+	success, err := ids.update(ctx, projectID, append(current, principal.Id.Resource))
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed adding %s to project: %w", ids.principalType.Id, err)
+	}
+	if !success {
+		return nil, fmt.Errorf("baton-linear: failed adding %s to project", ids.principalType.Id)
+	}
+
+	if err := ids.verify(ctx, projectID, principal.Id.Resource, true); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (o *projectResourceType) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	if entitlementSlug(grant.Entitlement) == lead {
+		return o.revokeLead(ctx, grant)
+	}
+
+	ids, err := o.idList(grant.Entitlement)
+	if err != nil {
+		return nil, err
+	}
+
+	principal := grant.Principal
+	if principal.Id.ResourceType != ids.principalType.Id {
+		l.Warn(
+			"baton-linear: principal type can't have this project entitlement revoked",
+			zap.String("entitlement", grant.Entitlement.Id),
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+		return nil, fmt.Errorf("baton-linear: only %ss can have %s revoked", ids.principalType.Id, entitlementSlug(grant.Entitlement))
+	}
+
+	projectID := grant.Entitlement.Resource.Id.Resource
+	unlock := o.locks.Lock(projectID)
+	defer unlock()
+
+	current, err := ids.list(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(current, principal.Id.Resource) {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	remaining := slices.DeleteFunc(current, func(id string) bool { return id == principal.Id.Resource })
+	if ids.required && len(remaining) == 0 {
+		return nil, fmt.Errorf("baton-linear: can't remove the last %s from a project, associate another %s first", ids.principalType.Id, ids.principalType.Id)
+	}
+
+	success, err := ids.update(ctx, projectID, remaining)
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed removing %s from project: %w", ids.principalType.Id, err)
+	}
+	if !success {
+		return nil, fmt.Errorf("baton-linear: failed removing %s from project", ids.principalType.Id)
+	}
+
+	if err := ids.verify(ctx, projectID, principal.Id.Resource, false); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// grantLead hands the project lead over to the principal.
+func (o *projectResourceType) grantLead(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	if principal.Id.ResourceType != resourceTypeUser.Id {
+		l.Warn(
+			"baton-linear: only users can lead a project",
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+		return nil, fmt.Errorf("baton-linear: only users can lead a project")
+	}
+
+	projectID := entitlement.Resource.Id.Resource
+	unlock := o.locks.Lock(projectID)
+	defer unlock()
+
+	current, err := o.client.GetProjectLead(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed getting project lead: %w", err)
+	}
+	if current != nil && current.ID == principal.Id.Resource {
+		return annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+
+	success, err := o.client.UpdateProjectLead(ctx, projectID, principal.Id.Resource)
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed setting project lead: %w", err)
+	}
+	if !success {
+		return nil, fmt.Errorf("baton-linear: failed setting project lead")
+	}
+
+	return nil, nil
+}
+
+// revokeLead never clears the lead. A project keeps its lead until the lead
+// entitlement is granted to someone else.
+func (o *projectResourceType) revokeLead(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	projectID := grant.Entitlement.Resource.Id.Resource
+	unlock := o.locks.Lock(projectID)
+	defer unlock()
+
+	current, err := o.client.GetProjectLead(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed getting project lead: %w", err)
+	}
+	if current == nil || current.ID != grant.Principal.Id.Resource {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	return nil, fmt.Errorf("baton-linear: can't leave a project without a lead, grant lead to another user instead")
+}
+
+// listMemberIDs pages through all members of a project.
+func (o *projectResourceType) listMemberIDs(ctx context.Context, projectID string) ([]string, error) {
+	var memberIDs []string
+	var after string
+	for {
+		members, nextToken, _, err := o.client.GetProjectMembers(ctx, linear.GetProjectVars{ProjectId: projectID, UsersAfter: after, First: resourcePageSize})
+		if err != nil {
+			return nil, fmt.Errorf("baton-linear: failed listing project members: %w", err)
+		}
+		for _, member := range members {
+			memberIDs = append(memberIDs, member.ID)
+		}
+		if nextToken == "" {
+			return memberIDs, nil
+		}
+		after = nextToken
+	}
+}
+
+// listTeamIDs pages through all teams associated with a project.
+func (o *projectResourceType) listTeamIDs(ctx context.Context, projectID string) ([]string, error) {
+	var teamIDs []string
+	var after string
+	for {
+		teams, nextToken, _, err := o.client.GetProjectTeams(ctx, linear.GetProjectVars{ProjectId: projectID, TeamsAfter: after, First: resourcePageSize})
+		if err != nil {
+			return nil, fmt.Errorf("baton-linear: failed listing project teams: %w", err)
+		}
+		for _, team := range teams {
+			teamIDs = append(teamIDs, team.ID)
+		}
+		if nextToken == "" {
+			return teamIDs, nil
+		}
+		after = nextToken
+	}
+}
+
+func projectBuilder(client *linear.Client, skipPrivateTeams bool, skipAppUsers bool) *projectResourceType {
 	return &projectResourceType{
-		resourceType: resourceTypeProject,
-		client:       client,
+		resourceType:     resourceTypeProject,
+		client:           client,
+		skipPrivateTeams: skipPrivateTeams,
+		skipAppUsers:     skipAppUsers,
+		locks:            newKeyedMutex(),
 	}
 }
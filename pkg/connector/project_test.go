@@ -0,0 +1,316 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/conductorone/baton-linear/pkg/linear"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+)
+
+// fakeProjectServer keeps a single project's member and team lists and its
+// lead, and applies projectUpdate mutations to them, like Linear does.
+type fakeProjectServer struct {
+	t       *testing.T
+	mu      sync.Mutex
+	members []string
+	teams   []string
+	lead    string
+	// dropMemberUpdates makes member updates report success without sticking,
+	// as if another writer replaced the list right after them.
+	dropMemberUpdates bool
+}
+
+func idNodes(ids []string) map[string]interface{} {
+	nodes := make([]map[string]string, 0, len(ids))
+	for _, id := range ids {
+		nodes = append(nodes, map[string]string{"id": id})
+	}
+	return map[string]interface{}{"nodes": nodes, "pageInfo": map[string]bool{"hasNextPage": false}}
+}
+
+func stringList(v interface{}) []string {
+	var rv []string
+	for _, id := range v.([]interface{}) {
+		rv = append(rv, id.(string))
+	}
+	return rv
+}
+
+func (f *fakeProjectServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		f.t.Errorf("failed to decode request: %v", err)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case strings.Contains(req.Query, "query ProjectMembers("):
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"project": map[string]interface{}{"id": "project-1", "members": idNodes(f.members)}},
+		})
+	case strings.Contains(req.Query, "query ProjectTeams("):
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"project": map[string]interface{}{"id": "project-1", "teams": idNodes(f.teams)}},
+		})
+	case strings.Contains(req.Query, "query ProjectLead("):
+		var leadNode interface{}
+		if f.lead != "" {
+			leadNode = map[string]string{"id": f.lead}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"project": map[string]interface{}{"id": "project-1", "lead": leadNode}},
+		})
+	case strings.Contains(req.Query, "projectUpdate("):
+		input := req.Variables["input"].(map[string]interface{})
+		if ids, ok := input["memberIds"]; ok && !f.dropMemberUpdates {
+			f.members = stringList(ids)
+		}
+		if ids, ok := input["teamIds"]; ok {
+			f.teams = stringList(ids)
+		}
+		if id, ok := input["leadId"]; ok {
+			f.lead = id.(string)
+		}
+		_, _ = w.Write([]byte(`{"data":{"projectUpdate":{"success":true}}}`))
+	default:
+		f.t.Errorf("unexpected query: %s", req.Query)
+	}
+}
+
+func newTestProjectBuilder(t *testing.T, handler http.Handler) *projectResourceType {
+	return projectBuilder(newTestClient(t, handler), false, false)
+}
+
+func testProjectResource(t *testing.T) *v2.Resource {
+	t.Helper()
+	pr, err := projectResource(&linear.Project{ID: "project-1", Name: "Roadmap"}, nil)
+	if err != nil {
+		t.Fatalf("projectResource: %v", err)
+	}
+	return pr
+}
+
+func TestProjectGrant_AddsMember(t *testing.T) {
+	fake := &fakeProjectServer{t: t, members: []string{"u1"}}
+	pb := newTestProjectBuilder(t, fake)
+	en := &v2.Entitlement{Resource: testProjectResource(t), Slug: membership}
+
+	annos, err := pb.Grant(context.Background(), testUserPrincipal("u2"), en)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if annos.Contains(&v2.GrantAlreadyExists{}) {
+		t.Error("did not expect GrantAlreadyExists")
+	}
+	if !slices.Equal(fake.members, []string{"u1", "u2"}) {
+		t.Errorf("members: got %v", fake.members)
+	}
+
+	annos, err = pb.Grant(context.Background(), testUserPrincipal("u2"), en)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !annos.Contains(&v2.GrantAlreadyExists{}) {
+		t.Error("expected GrantAlreadyExists for an existing member")
+	}
+}
+
+func TestProjectGrant_ConcurrentGrantsKeepAllMembers(t *testing.T) {
+	fake := &fakeProjectServer{t: t}
+	pb := newTestProjectBuilder(t, fake)
+	en := &v2.Entitlement{Resource: testProjectResource(t), Slug: membership}
+
+	var wg sync.WaitGroup
+	for _, id := range []string{"u1", "u2", "u3", "u4", "u5"} {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			if _, err := pb.Grant(context.Background(), testUserPrincipal(id), en); err != nil {
+				t.Errorf("grant %s: %v", id, err)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	got := slices.Clone(fake.members)
+	slices.Sort(got)
+	if !slices.Equal(got, []string{"u1", "u2", "u3", "u4", "u5"}) {
+		t.Errorf("members: got %v", got)
+	}
+}
+
+func TestProjectGrant_DetectsClobberedUpdate(t *testing.T) {
+	fake := &fakeProjectServer{t: t, members: []string{"u1"}, dropMemberUpdates: true}
+	pb := newTestProjectBuilder(t, fake)
+	en := &v2.Entitlement{Resource: testProjectResource(t), Slug: membership}
+
+	if _, err := pb.Grant(context.Background(), testUserPrincipal("u2"), en); err == nil {
+		t.Fatal("expected an error when the member update doesn't stick")
+	}
+}
+
+func TestKeyedMutex_PrunesReleasedLocks(t *testing.T) {
+	locks := newKeyedMutex()
+	unlock := locks.Lock("project-1")
+	if len(locks.locks) != 1 {
+		t.Fatalf("expected one held lock, got %d", len(locks.locks))
+	}
+	unlock()
+	if len(locks.locks) != 0 {
+		t.Errorf("expected the released lock to be pruned, got %d", len(locks.locks))
+	}
+}
+
+func TestProjectRevoke_RemovesMember(t *testing.T) {
+	fake := &fakeProjectServer{t: t, members: []string{"u1", "u2"}}
+	pb := newTestProjectBuilder(t, fake)
+	g := grant.NewGrant(testProjectResource(t), membership, testUserPrincipal("u1").Id)
+
+	if _, err := pb.Revoke(context.Background(), g); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(fake.members, []string{"u2"}) {
+		t.Errorf("members: got %v", fake.members)
+	}
+
+	annos, err := pb.Revoke(context.Background(), g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !annos.Contains(&v2.GrantAlreadyRevoked{}) {
+		t.Error("expected GrantAlreadyRevoked for a non-member")
+	}
+}
+
+func testTeamPrincipal(id string) *v2.Resource {
+	return &v2.Resource{Id: &v2.ResourceId{ResourceType: resourceTypeTeam.Id, Resource: id}}
+}
+
+func TestProjectGrant_AssociatesTeam(t *testing.T) {
+	fake := &fakeProjectServer{t: t, teams: []string{"t1"}}
+	pb := newTestProjectBuilder(t, fake)
+	en := &v2.Entitlement{Resource: testProjectResource(t), Slug: associated}
+
+	if _, err := pb.Grant(context.Background(), testTeamPrincipal("t2"), en); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(fake.teams, []string{"t1", "t2"}) {
+		t.Errorf("teams: got %v", fake.teams)
+	}
+
+	if _, err := pb.Grant(context.Background(), testUserPrincipal("u1"), en); err == nil {
+		t.Error("expected error granting a team entitlement to a user")
+	}
+}
+
+func TestProjectRevoke_RefusesLastTeam(t *testing.T) {
+	fake := &fakeProjectServer{t: t, teams: []string{"t1", "t2"}}
+	pb := newTestProjectBuilder(t, fake)
+
+	if _, err := pb.Revoke(context.Background(), grant.NewGrant(testProjectResource(t), associated, testTeamPrincipal("t1").Id)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(fake.teams, []string{"t2"}) {
+		t.Errorf("teams: got %v", fake.teams)
+	}
+
+	_, err := pb.Revoke(context.Background(), grant.NewGrant(testProjectResource(t), associated, testTeamPrincipal("t2").Id))
+	if err == nil || !strings.Contains(err.Error(), "last team") {
+		t.Fatalf("expected last team error, got %v", err)
+	}
+	if !slices.Equal(fake.teams, []string{"t2"}) {
+		t.Errorf("teams should be unchanged, got %v", fake.teams)
+	}
+}
+
+func TestProjectGrantLead(t *testing.T) {
+	server := &fakeProjectServer{t: t, lead: "u1"}
+	pb := newTestProjectBuilder(t, server)
+	en := &v2.Entitlement{Resource: testProjectResource(t), Slug: lead}
+
+	annos, err := pb.Grant(context.Background(), testUserPrincipal("u1"), en)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !annos.Contains(&v2.GrantAlreadyExists{}) {
+		t.Errorf("expected GrantAlreadyExists for the current lead")
+	}
+
+	if _, err := pb.Grant(context.Background(), testUserPrincipal("u2"), en); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if server.lead != "u2" {
+		t.Errorf("expected u2 to lead the project, got %q", server.lead)
+	}
+
+	if _, err := pb.Grant(context.Background(), testTeamPrincipal("team-1"), en); err == nil {
+		t.Error("expected error granting lead to a team")
+	}
+}
+
+func TestProjectRevokeLead(t *testing.T) {
+	server := &fakeProjectServer{t: t, lead: "u1"}
+	pb := newTestProjectBuilder(t, server)
+
+	annos, err := pb.Revoke(context.Background(), grant.NewGrant(testProjectResource(t), lead, testUserPrincipal("u2").Id))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !annos.Contains(&v2.GrantAlreadyRevoked{}) {
+		t.Errorf("expected GrantAlreadyRevoked for a former lead")
+	}
+
+	_, err = pb.Revoke(context.Background(), grant.NewGrant(testProjectResource(t), lead, testUserPrincipal("u1").Id))
+	if err == nil || !strings.Contains(err.Error(), "without a lead") {
+		t.Fatalf("expected refusal to leave the project without a lead, got %v", err)
+	}
+	if server.lead != "u1" {
+		t.Errorf("lead should be unchanged, got %q", server.lead)
+	}
+}
+
+func TestProjectGrants_SkipsPrivateTeams(t *testing.T) {
+	pb := projectBuilder(newTestClient(t, serveFixtures(t, map[string]string{
+		"query Project(": `{"data":{"project":{"id":"project-1","name":"Roadmap",
+			"teams":{"nodes":[{"id":"team-1","name":"Engineering"},{"id":"team-2","name":"Security","private":true}],"pageInfo":{"hasNextPage":false}},
+			"members":{"nodes":[],"pageInfo":{"hasNextPage":false}}}}}`,
+	})), true, false)
+
+	grants, _, _, err := pb.Grants(context.Background(), testProjectResource(t), &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grants) != 1 || grants[0].Principal.Id.Resource != "team-1" {
+		t.Errorf("expected only the public team to be associated, got %v", grants)
+	}
+}
+
+func TestProjectGrants_SkipsAppUsers(t *testing.T) {
+	pb := projectBuilder(newTestClient(t, serveFixtures(t, map[string]string{
+		"query Project(": `{"data":{"project":{"id":"project-1","name":"Roadmap","lead":{"id":"bot-1","app":true},
+			"teams":{"nodes":[],"pageInfo":{"hasNextPage":false}},
+			"members":{"nodes":[{"id":"u1","name":"Ada"},{"id":"bot-2","name":"GitHub","app":true}],"pageInfo":{"hasNextPage":false}}}}}`,
+	})), false, true)
+
+	grants, _, _, err := pb.Grants(context.Background(), testProjectResource(t), &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grants) != 1 || grants[0].Principal.Id.Resource != "u1" {
+		t.Errorf("expected only the human member's grant, got %v", grants)
+	}
+}
@@ -10,14 +10,21 @@ import (
 	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
 	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
 	resource "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
 )
 
-var _ connectorbuilder.ResourceSyncer = (*roleResourceType)(nil)
+var (
+	_ connectorbuilder.ResourceSyncer      = (*roleResourceType)(nil)
+	_ connectorbuilder.ResourceProvisioner = (*roleResourceType)(nil)
+)
 
 type roleResourceType struct {
 	resourceType *v2.ResourceType
 	client       *linear.Client
+	skipAppUsers bool
 }
 
 func (o *roleResourceType) ResourceType(_ context.Context) *v2.ResourceType {
@@ -38,6 +45,21 @@ var roles = []string{
 	roleOwner,
 }
 
+// userRole derives the single workspace role a Linear user holds from the
+// owner/admin/guest flags, in order of precedence.
+func userRole(user *linear.User) string {
+	switch {
+	case user.Owner:
+		return roleOwner
+	case user.Admin:
+		return roleAdmin
+	case user.Guest:
+		return roleGuest
+	default:
+		return roleUser
+	}
+}
+
 // Create a new connector resource for a Linear role.
 func roleResource(ctx context.Context, role string, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
 	roleDisplayName := titleCase(role)
@@ -94,13 +116,152 @@ func (o *roleResourceType) Entitlements(_ context.Context, resource *v2.Resource
 	return rv, "", nil, nil
 }
 
+// Grants pages through the workspace users and emits a membership grant for
+// every user whose owner/admin/guest flags resolve to this role.
 func (o *roleResourceType) Grants(ctx context.Context, resource *v2.Resource, token *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
-	return nil, "", nil, nil
+	var annotations annotations.Annotations
+	bag, err := parsePageToken(token.Token, resource.Id)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	users, nextToken, rlData, err := o.client.GetUsers(ctx, linear.GetResourcesVars{First: resourcePageSize, After: bag.PageToken()})
+	annotations.WithRateLimiting(rlData)
+	if err != nil {
+		return nil, "", annotations, fmt.Errorf("linear-connector: failed to list users: %w", err)
+	}
+
+	pageToken, err := bag.NextToken(nextToken)
+	if err != nil {
+		return nil, "", annotations, err
+	}
+
+	var rv []*v2.Grant
+	for _, user := range users {
+		userCopy := user
+		if userRole(&userCopy) != resource.Id.Resource || (user.App && o.skipAppUsers) {
+			continue
+		}
+
+		ur, err := userResource(ctx, &userCopy, resource.ParentResourceId)
+		if err != nil {
+			return nil, "", annotations, err
+		}
+
+		rv = append(rv, grant.NewGrant(resource, membership, ur.Id))
+	}
+
+	return rv, pageToken, annotations, nil
+}
+
+// Grant moves a user into the role. Linear users hold exactly one role, so
+// granting a role replaces whatever role the user held before.
+func (o *roleResourceType) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	if principal.Id.ResourceType != resourceTypeUser.Id {
+		l.Warn(
+			"baton-linear: only users can be granted a role",
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+		return nil, fmt.Errorf("baton-linear: only users can be granted a role")
+	}
+
+	role := entitlement.Resource.Id.Resource
+	if role == roleOwner {
+		return nil, fmt.Errorf("baton-linear: the owner role can only be changed with the transfer_ownership action")
+	}
+
+	user, _, err := o.client.GetUser(ctx, principal.Id.Resource)
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed to get user: %w", err)
+	}
+
+	currentRole := userRole(&user)
+	if currentRole == role {
+		return annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+	if currentRole == roleOwner {
+		return nil, fmt.Errorf("baton-linear: the owner role can only be changed with the transfer_ownership action")
+	}
+	if err := guardLastAdmin(ctx, o.client, &user); err != nil {
+		return nil, err
+	}
+
+	if err := changeRole(ctx, o.client, user.ID, currentRole, role); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// Revoke moves a user out of the admin or guest role and back to a regular
+// member. The user role can't be revoked on its own since every Linear user
+// must hold a role; grant admin or guest instead.
+func (o *roleResourceType) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	principal := grant.Principal
+	if principal.Id.ResourceType != resourceTypeUser.Id {
+		l.Warn(
+			"baton-linear: only users can have a role revoked",
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+		return nil, fmt.Errorf("baton-linear: only users can have a role revoked")
+	}
+
+	role := grant.Entitlement.Resource.Id.Resource
+	switch role {
+	case roleOwner:
+		return nil, fmt.Errorf("baton-linear: the owner role can only be changed with the transfer_ownership action")
+	case roleUser:
+		return nil, fmt.Errorf("baton-linear: the user role can't be revoked, grant the admin or guest role instead")
+	}
+
+	user, _, err := o.client.GetUser(ctx, principal.Id.Resource)
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed to get user: %w", err)
+	}
+
+	currentRole := userRole(&user)
+	if currentRole != role {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+	if err := guardLastAdmin(ctx, o.client, &user); err != nil {
+		return nil, err
+	}
+
+	if err := changeRole(ctx, o.client, user.ID, currentRole, roleUser); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// changeRole moves a user from one role to another. The role names match
+// Linear's UserRoleType, so a single userChangeRole mutation does it.
+func changeRole(ctx context.Context, client *linear.Client, userID string, from string, to string) error {
+	if from == roleOwner {
+		return fmt.Errorf("baton-linear: owners can only be demoted with the transfer_ownership action")
+	}
+
+	success, err := client.SetUserRole(ctx, userID, to)
+	if err != nil {
+		return fmt.Errorf("baton-linear: failed changing user role from %s to %s: %w", from, to, err)
+	}
+	if !success {
+		return fmt.Errorf("baton-linear: failed changing user role from %s to %s", from, to)
+	}
+
+	return nil
 }
 
-func roleBuilder(client *linear.Client) *roleResourceType {
+func roleBuilder(client *linear.Client, skipAppUsers bool) *roleResourceType {
 	return &roleResourceType{
 		resourceType: resourceTypeRole,
 		client:       client,
+		skipAppUsers: skipAppUsers,
 	}
 }
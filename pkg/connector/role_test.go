@@ -0,0 +1,212 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestRoleBuilder(t *testing.T, handler http.HandlerFunc) *roleResourceType {
+	return roleBuilder(newTestClient(t, handler), false)
+}
+
+// roleTestServer answers the user lookup with the given JSON user, lists it as
+// the workspace's only user, and records the roles it's asked to change the
+// user to, in order.
+func roleTestServer(t *testing.T, userJSON string, mutations *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = decodeJSON(t, r, &req)
+		query := req["query"].(string)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(query, "query User(") {
+			_, _ = w.Write([]byte(`{"data":{"user":` + userJSON + `}}`))
+			return
+		}
+		if strings.Contains(query, "query Users(") {
+			_, _ = w.Write([]byte(`{"data":{"users":{"nodes":[` + userJSON + `],"pageInfo":{"hasNextPage":false}}}}`))
+			return
+		}
+		if strings.Contains(query, "userChangeRole(") {
+			vars := req["variables"].(map[string]interface{})
+			*mutations = append(*mutations, vars["role"].(string))
+			_, _ = w.Write([]byte(`{"data":{"userChangeRole":{"success":true}}}`))
+			return
+		}
+		t.Errorf("unexpected query: %s", query)
+	}
+}
+
+func testRoleResource(t *testing.T, role string) *v2.Resource {
+	t.Helper()
+	rr, err := roleResource(context.Background(), role, &v2.ResourceId{ResourceType: resourceTypeOrg.Id, Resource: "org-1"})
+	if err != nil {
+		t.Fatalf("roleResource: %v", err)
+	}
+	return rr
+}
+
+func testUserPrincipal(id string) *v2.Resource {
+	return &v2.Resource{Id: &v2.ResourceId{ResourceType: resourceTypeUser.Id, Resource: id}}
+}
+
+func TestRoleGrant_ChangesRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     string
+		role     string
+		wantCall []string
+	}{
+		{"user to admin", `{"id":"u1"}`, roleAdmin, []string{roleAdmin}},
+		{"guest to admin", `{"id":"u1","guest":true}`, roleAdmin, []string{roleAdmin}},
+		{"admin to user", `{"id":"u1","admin":true}`, roleUser, []string{roleUser}},
+		{"admin to guest", `{"id":"u1","admin":true}`, roleGuest, []string{roleGuest}},
+		{"user to guest", `{"id":"u1"}`, roleGuest, []string{roleGuest}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			rb := newTestRoleBuilder(t, roleTestServer(t, tt.user, &calls))
+			rr := testRoleResource(t, tt.role)
+			en := &v2.Entitlement{Resource: rr}
+
+			if _, err := rb.Grant(context.Background(), testUserPrincipal("u1"), en); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Join(calls, ",") != strings.Join(tt.wantCall, ",") {
+				t.Errorf("mutations: want %v got %v", tt.wantCall, calls)
+			}
+		})
+	}
+}
+
+func TestRoleGrant_AlreadyHasRole(t *testing.T) {
+	var calls []string
+	rb := newTestRoleBuilder(t, roleTestServer(t, `{"id":"u1","admin":true}`, &calls))
+	en := &v2.Entitlement{Resource: testRoleResource(t, roleAdmin)}
+
+	annos, err := rb.Grant(context.Background(), testUserPrincipal("u1"), en)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !annos.Contains(&v2.GrantAlreadyExists{}) {
+		t.Errorf("expected GrantAlreadyExists annotation, got %v", annos)
+	}
+	if len(calls) != 0 {
+		t.Errorf("expected no mutations, got %v", calls)
+	}
+}
+
+func TestRoleGrant_OwnerRefused(t *testing.T) {
+	rb := newTestRoleBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("API should not be called when granting owner")
+	})
+	en := &v2.Entitlement{Resource: testRoleResource(t, roleOwner)}
+
+	_, err := rb.Grant(context.Background(), testUserPrincipal("u1"), en)
+	if err == nil || !strings.Contains(err.Error(), "owner") {
+		t.Fatalf("expected owner error, got %v", err)
+	}
+}
+
+// Owners are refused before the workspace is scanned for other admins, which
+// serveFixtures would reject as an unexpected query.
+func TestRoleGrant_OwnerPrincipalRefused(t *testing.T) {
+	rb := newTestRoleBuilder(t, serveFixtures(t, map[string]string{
+		"query User(": `{"data":{"user":{"id":"u1","active":true,"owner":true}}}`,
+	}))
+	en := &v2.Entitlement{Resource: testRoleResource(t, roleAdmin)}
+
+	_, err := rb.Grant(context.Background(), testUserPrincipal("u1"), en)
+	if err == nil || !strings.Contains(err.Error(), "transfer_ownership") {
+		t.Fatalf("expected transfer_ownership error, got %v", err)
+	}
+}
+
+func TestRoleRevoke(t *testing.T) {
+	tests := []struct {
+		name        string
+		user        string
+		role        string
+		wantCall    []string
+		wantRevoked bool
+		wantErr     bool
+	}{
+		{name: "admin back to user", user: `{"id":"u1","admin":true}`, role: roleAdmin, wantCall: []string{roleUser}},
+		{name: "guest back to user", user: `{"id":"u1","guest":true}`, role: roleGuest, wantCall: []string{roleUser}},
+		{name: "no longer admin", user: `{"id":"u1"}`, role: roleAdmin, wantRevoked: true},
+		{name: "user role refused", user: `{"id":"u1"}`, role: roleUser, wantErr: true},
+		{name: "owner role refused", user: `{"id":"u1","owner":true}`, role: roleOwner, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			rb := newTestRoleBuilder(t, roleTestServer(t, tt.user, &calls))
+			g := grant.NewGrant(testRoleResource(t, tt.role), membership, testUserPrincipal("u1").Id)
+
+			annos, err := rb.Revoke(context.Background(), g)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := annos.Contains(&v2.GrantAlreadyRevoked{}); got != tt.wantRevoked {
+				t.Errorf("GrantAlreadyRevoked: want %v got %v", tt.wantRevoked, got)
+			}
+			if strings.Join(calls, ",") != strings.Join(tt.wantCall, ",") {
+				t.Errorf("mutations: want %v got %v", tt.wantCall, calls)
+			}
+		})
+	}
+}
+
+func TestRoleRevoke_LastAdmin(t *testing.T) {
+	var calls []string
+	rb := newTestRoleBuilder(t, roleTestServer(t, `{"id":"u1","active":true,"admin":true}`, &calls))
+	g := grant.NewGrant(testRoleResource(t, roleAdmin), membership, testUserPrincipal("u1").Id)
+
+	_, err := rb.Revoke(context.Background(), g)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("expected no mutations, got %v", calls)
+	}
+}
+
+func TestRoleGrants_FiltersByRole(t *testing.T) {
+	rb := newTestRoleBuilder(t, serveFixtures(t, map[string]string{
+		"query Users(": `{"data":{"users":{"nodes":[
+			{"id":"u-owner","owner":true,"admin":true},
+			{"id":"u-admin","admin":true},
+			{"id":"u-guest","guest":true},
+			{"id":"u-user"}
+		],"pageInfo":{"hasNextPage":false}}}}`,
+	}))
+
+	for role, want := range map[string]string{
+		roleOwner: "u-owner",
+		roleAdmin: "u-admin",
+		roleGuest: "u-guest",
+		roleUser:  "u-user",
+	} {
+		grants, _, _, err := rb.Grants(context.Background(), testRoleResource(t, role), &pagination.Token{})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", role, err)
+		}
+		if len(grants) != 1 || grants[0].Principal.Id.Resource != want {
+			t.Errorf("%s: want a single grant to %s, got %v", role, want, grants)
+		}
+	}
+}
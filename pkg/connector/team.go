@@ -3,9 +3,12 @@ package connector
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/conductorone/baton-linear/pkg/linear"
+	config "github.com/conductorone/baton-sdk/pb/c1/config/v1"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/actions"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
@@ -18,15 +21,52 @@ import (
 )
 
 var (
-	_ connectorbuilder.ResourceSyncer      = (*teamResourceType)(nil)
-	_ connectorbuilder.ResourceProvisioner = (*teamResourceType)(nil)
+	_ connectorbuilder.ResourceSyncer               = (*teamResourceType)(nil)
+	_ connectorbuilder.ResourceProvisionerV2Limited = (*teamResourceType)(nil)
+	_ connectorbuilder.ResourceManagerLimited       = (*teamResourceType)(nil)
+	_ connectorbuilder.ResourceActionProvider       = (*teamResourceType)(nil)
 )
 
-const memberEntitlement = "member"
+const (
+	memberEntitlement = "member"
+	ownerEntitlement  = "owner"
+
+	teamKeyProfileKey     = "team_key"
+	teamPrivateProfileKey = "private"
+
+	unarchiveTeamActionName = "unarchive_team"
+)
+
+var unarchiveTeamActionSchema = &v2.BatonActionSchema{
+	Name:        unarchiveTeamActionName,
+	DisplayName: "Unarchive team",
+	Description: "Restore a deleted Linear team before it is removed for good.",
+	Arguments: []*config.Field{
+		{
+			Name:        "resource_id",
+			DisplayName: "Team",
+			Description: "The team to restore.",
+			Field:       &config.Field_ResourceIdField{ResourceIdField: &config.ResourceIdField{}},
+			IsRequired:  true,
+		},
+	},
+	ReturnTypes: []*config.Field{
+		{
+			Name:        "success",
+			DisplayName: "Success",
+			Field:       &config.Field_BoolField{BoolField: &config.BoolField{}},
+		},
+	},
+	ActionType: []v2.ActionType{
+		v2.ActionType_ACTION_TYPE_RESOURCE_ENABLE,
+	},
+}
 
 type teamResourceType struct {
-	resourceType *v2.ResourceType
-	client       *linear.Client
+	resourceType     *v2.ResourceType
+	client           *linear.Client
+	skipPrivateTeams bool
+	skipAppUsers     bool
 }
 
 func (o *teamResourceType) ResourceType(_ context.Context) *v2.ResourceType {
@@ -36,8 +76,10 @@ func (o *teamResourceType) ResourceType(_ context.Context) *v2.ResourceType {
 // Create a new connector resource for a Linear team.
 func teamResource(team *linear.Team, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
 	profile := map[string]interface{}{
-		"team_id":   team.ID,
-		"team_name": team.Name,
+		"team_id":             team.ID,
+		"team_name":           team.Name,
+		teamKeyProfileKey:     team.Key,
+		teamPrivateProfileKey: team.Private,
 	}
 
 	groupTraitOptions := []rs.GroupTraitOption{}
@@ -49,6 +91,7 @@ func teamResource(team *linear.Team, parentResourceID *v2.ResourceId) (*v2.Resou
 		groupTraitOptions,
 		rs.WithResourceProfile(profile),
 		rs.WithParentResourceID(parentResourceID),
+		rs.WithAnnotation(&v2.ChildResourceType{ResourceTypeId: resourceTypeTeam.Id}),
 	)
 	if err != nil {
 		return nil, err
@@ -68,7 +111,16 @@ func (o *teamResourceType) List(ctx context.Context, parentId *v2.ResourceId, to
 		return nil, "", nil, err
 	}
 
-	teams, nextToken, rlData, err := o.client.GetTeams(ctx, linear.GetResourcesVars{After: bag.PageToken(), First: resourcePageSize})
+	// Sub-teams are filtered by Linear, so listing the children of every team
+	// costs one request each instead of paging through all teams again.
+	var teams []linear.Team
+	var nextToken string
+	var rlData *v2.RateLimitDescription
+	if parentId.GetResourceType() == resourceTypeTeam.Id {
+		teams, nextToken, rlData, err = o.client.GetSubTeams(ctx, linear.GetSubTeamsVars{ParentID: parentId.GetResource(), After: bag.PageToken(), First: resourcePageSize})
+	} else {
+		teams, nextToken, rlData, err = o.client.GetTeams(ctx, linear.GetResourcesVars{After: bag.PageToken(), First: resourcePageSize})
+	}
 	annotations.WithRateLimiting(rlData)
 	if err != nil {
 		return nil, "", annotations, fmt.Errorf("linear-connector: failed to list teams: %w", err)
@@ -81,6 +133,13 @@ func (o *teamResourceType) List(ctx context.Context, parentId *v2.ResourceId, to
 
 	var rv []*v2.Resource
 	for _, team := range teams {
+		if team.Private && o.skipPrivateTeams {
+			continue
+		}
+		if parentId.GetResourceType() != resourceTypeTeam.Id && o.isParentSynced(&team) {
+			continue
+		}
+
 		teamCopy := team
 		ur, err := teamResource(&teamCopy, parentId)
 		if err != nil {
@@ -96,7 +155,7 @@ func (o *teamResourceType) Entitlements(_ context.Context, resource *v2.Resource
 	var rv []*v2.Entitlement
 
 	assigmentOptions := []ent.EntitlementOption{
-		ent.WithGrantableTo(resourceTypeUser),
+		ent.WithGrantableTo(resourceTypeUser, resourceTypeTeam),
 		ent.WithDescription(fmt.Sprintf("Member of %s team in Linear", resource.DisplayName)),
 		ent.WithDisplayName(fmt.Sprintf("%s Team %s", resource.DisplayName, memberEntitlement)),
 	}
@@ -104,6 +163,14 @@ func (o *teamResourceType) Entitlements(_ context.Context, resource *v2.Resource
 	en := ent.NewAssignmentEntitlement(resource, memberEntitlement, assigmentOptions...)
 	rv = append(rv, en)
 
+	ownerOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser),
+		ent.WithDescription(fmt.Sprintf("Owner of %s team in Linear", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s Team %s", resource.DisplayName, ownerEntitlement)),
+	}
+
+	rv = append(rv, ent.NewAssignmentEntitlement(resource, ownerEntitlement, ownerOptions...))
+
 	return rv, "", nil, nil
 }
 
@@ -127,25 +194,69 @@ func (o *teamResourceType) Grants(ctx context.Context, resource *v2.Resource, to
 		return nil, "", annotations, err
 	}
 
+	// Sub-teams inherit the members of their parent team, so the parent's
+	// members are granted membership here through an expandable grant.
+	if isFirstPage(token) && o.isParentSynced(&team) {
+		parentID := &v2.ResourceId{ResourceType: resourceTypeTeam.Id, Resource: team.Parent.ID}
+		rv = append(rv, grant.NewGrant(resource, memberEntitlement, parentID,
+			grant.WithAnnotation(&v2.GrantExpandable{
+				EntitlementIds: []string{ent.NewEntitlementID(&v2.Resource{Id: parentID}, memberEntitlement)},
+			}),
+		))
+	}
+
 	for _, membership := range team.Memberships.Nodes {
+		if membership.User.App && o.skipAppUsers {
+			continue
+		}
+
 		membershipCopy := membership
 		ur, err := userResource(ctx, &membershipCopy.User, resource.Id)
 		if err != nil {
 			return nil, "", annotations, err
 		}
 
-		metadata := map[string]interface{}{
-			"membership_id": membership.ID,
-		}
+		rv = append(rv, membershipGrant(resource, memberEntitlement, ur.Id, membership.ID))
 
-		grant := grant.NewGrant(resource, memberEntitlement, ur.Id, grant.WithGrantMetadata(metadata))
-		rv = append(rv, grant)
+		if membership.Owner {
+			rv = append(rv, membershipGrant(resource, ownerEntitlement, ur.Id, membership.ID))
+		}
 	}
 
 	return rv, pageToken, annotations, nil
 }
 
-func (o *teamResourceType) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+// membershipGrant builds a team grant carrying the ID of the membership it
+// comes from, which Revoke checks against the current membership. Grants in
+// private teams are flagged so they can be reviewed separately.
+func membershipGrant(team *v2.Resource, slug string, principalID *v2.ResourceId, membershipID string) *v2.Grant {
+	metadata := map[string]interface{}{
+		"membership_id": membershipID,
+	}
+	if isPrivateTeam(team) {
+		metadata["private_team"] = true
+	}
+	return grant.NewGrant(team, slug, principalID, grant.WithGrantMetadata(metadata))
+}
+
+// isParentSynced reports whether a team has a parent team that is synced too,
+// in which case the team is listed under its parent instead of the org. A
+// sub-team of a skipped private team is listed under the org so that it, and
+// the grants pointing at it, aren't lost.
+func (o *teamResourceType) isParentSynced(team *linear.Team) bool {
+	if team.Parent == nil {
+		return false
+	}
+	return !team.Parent.Private || !o.skipPrivateTeams
+}
+
+// isPrivateTeam reports whether a team resource was synced as private.
+func isPrivateTeam(team *v2.Resource) bool {
+	private, ok := team.GetProfile().GetFields()[teamPrivateProfileKey]
+	return ok && private.GetBoolValue()
+}
+
+func (o *teamResourceType) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
 	l := ctxzap.Extract(ctx)
 
 	if principal.Id.ResourceType != resourceTypeUser.Id {
@@ -154,14 +265,52 @@ func (o *teamResourceType) Grant(ctx context.Context, principal *v2.Resource, en
 			zap.String("principal_type", principal.Id.ResourceType),
 			zap.String("principal_id", principal.Id.Resource),
 		)
-		return nil, fmt.Errorf("baton-linear: only users can be granted team membership")
+		return nil, nil, fmt.Errorf("baton-linear: only users can be granted team membership, teams inherit it from their parent team")
 	}
 
-	_, err := o.client.AddMemberToTeam(ctx, entitlement.Resource.Id.Resource, principal.Id.Resource)
+	team := entitlement.Resource
+	membership, err := o.client.GetTeamMembership(ctx, team.Id.Resource, principal.Id.Resource)
 	if err != nil {
-		return nil, fmt.Errorf("baton-linear: failed adding user to team: %w", err)
+		return nil, nil, err
 	}
-	return nil, nil
+
+	if entitlementSlug(entitlement) == ownerEntitlement {
+		return o.grantOwner(ctx, team, principal.Id, membership)
+	}
+
+	if membership != nil {
+		return []*v2.Grant{membershipGrant(team, memberEntitlement, principal.Id, membership.ID)}, annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+
+	membershipID, err := o.client.AddMemberToTeam(ctx, team.Id.Resource, principal.Id.Resource)
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-linear: failed adding user to team: %w", err)
+	}
+
+	return []*v2.Grant{membershipGrant(team, memberEntitlement, principal.Id, membershipID)}, nil, nil
+}
+
+// grantOwner makes an existing team member an owner of the team. Ownership
+// lives on the membership, so the user has to be a member first.
+func (o *teamResourceType) grantOwner(ctx context.Context, team *v2.Resource, principalID *v2.ResourceId, membership *linear.TeamMembership) ([]*v2.Grant, annotations.Annotations, error) {
+	if membership == nil {
+		return nil, nil, fmt.Errorf("baton-linear: user must be a member of the team before being made an owner")
+	}
+
+	grants := []*v2.Grant{membershipGrant(team, ownerEntitlement, principalID, membership.ID)}
+	if membership.Owner {
+		return grants, annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+
+	success, err := o.client.UpdateTeamMembership(ctx, membership.ID, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-linear: failed making user a team owner: %w", err)
+	}
+	if !success {
+		return nil, nil, fmt.Errorf("baton-linear: failed making user a team owner")
+	}
+
+	return grants, nil, nil
 }
 
 func (o *teamResourceType) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
@@ -178,20 +327,28 @@ func (o *teamResourceType) Revoke(ctx context.Context, grant *v2.Grant) (annotat
 		return nil, fmt.Errorf("baton-linear: only users can have team membership revoked")
 	}
 
-	metadata := &structpb.Struct{}
-	annos := annotations.Annotations(grant.Annotations)
-	ok, err := annos.Pick(metadata)
-	if err != nil {
-		return nil, err
+	if entitlementSlug(grant.Entitlement) == ownerEntitlement {
+		return o.revokeOwner(ctx, grant.Entitlement.Resource.Id.Resource, principal.Id.Resource)
 	}
 
-	if !ok {
-		return nil, fmt.Errorf("baton-linear: annotation does not exist: %w", err)
+	// The membership ID stored on the grant can be stale if the user left and
+	// rejoined the team since the last sync, so always confirm it first.
+	membership, err := o.client.GetTeamMembership(ctx, grant.Entitlement.Resource.Id.Resource, principal.Id.Resource)
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed looking up team membership: %w", err)
+	}
+	if membership == nil {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+	if stored := grantMembershipID(grant); stored != "" && stored != membership.ID {
+		l.Debug(
+			"baton-linear: grant carries a stale team membership ID",
+			zap.String("stored_membership_id", stored),
+			zap.String("membership_id", membership.ID),
+		)
 	}
 
-	membershipId := metadata.Fields["membership_id"].GetStringValue()
-
-	success, err := o.client.RemoveTeamMembership(ctx, membershipId)
+	success, err := o.client.RemoveTeamMembership(ctx, membership.ID)
 	if err != nil {
 		return nil, fmt.Errorf("baton-linear: failed removing user from team: %w", err)
 	}
@@ -203,9 +360,121 @@ func (o *teamResourceType) Revoke(ctx context.Context, grant *v2.Grant) (annotat
 	return nil, nil
 }
 
-func teamBuilder(client *linear.Client) *teamResourceType {
+// revokeOwner turns a team owner back into a regular member of the team.
+func (o *teamResourceType) revokeOwner(ctx context.Context, teamID string, userID string) (annotations.Annotations, error) {
+	membership, err := o.client.GetTeamMembership(ctx, teamID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if membership == nil || !membership.Owner {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	success, err := o.client.UpdateTeamMembership(ctx, membership.ID, false)
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed removing user as team owner: %w", err)
+	}
+	if !success {
+		return nil, fmt.Errorf("baton-linear: failed removing user as team owner")
+	}
+
+	return nil, nil
+}
+
+// grantMembershipID returns the membership ID attached to a grant by Grants or
+// Grant, or "" when the grant doesn't carry one.
+func grantMembershipID(grant *v2.Grant) string {
+	annos := annotations.Annotations(grant.Annotations)
+
+	grantMetadata := &v2.GrantMetadata{}
+	if ok, err := annos.Pick(grantMetadata); err == nil && ok {
+		return grantMetadata.GetMetadata().GetFields()["membership_id"].GetStringValue()
+	}
+
+	// Older grants carried the metadata as a bare struct annotation.
+	metadata := &structpb.Struct{}
+	if ok, err := annos.Pick(metadata); err == nil && ok {
+		return metadata.GetFields()["membership_id"].GetStringValue()
+	}
+
+	return ""
+}
+
+// Create creates a team from the resource's display name and description. The
+// team key is read from the profile; Linear derives one when it's missing.
+func (o *teamResourceType) Create(ctx context.Context, resource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	if resource.GetId().GetResourceType() != "" && resource.GetId().GetResourceType() != resourceTypeTeam.Id {
+		return nil, nil, fmt.Errorf("baton-linear: non-team resource passed to team create: %s", resource.GetId().GetResourceType())
+	}
+
+	name := strings.TrimSpace(resource.GetDisplayName())
+	if name == "" {
+		return nil, nil, fmt.Errorf("baton-linear: a name is required to create a team")
+	}
+
+	key, _ := rs.GetProfileStringValue(resource.GetProfile(), teamKeyProfileKey)
+
+	team, err := o.client.CreateTeam(ctx, name, strings.ToUpper(strings.TrimSpace(key)), resource.GetDescription())
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-linear: failed to create team: %w", err)
+	}
+
+	tr, err := teamResource(&team, resource.GetParentResourceId())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tr, nil, nil
+}
+
+// Delete archives a team. Linear has no separate archive mutation: teamDelete
+// archives the team and only removes it for good once the retention period is
+// over, and until then the unarchive_team action can restore it. There is no
+// way to delete a team immediately through the API.
+func (o *teamResourceType) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	if resourceId.GetResourceType() != resourceTypeTeam.Id {
+		return nil, fmt.Errorf("baton-linear: non-team resource passed to team delete: %s", resourceId.GetResourceType())
+	}
+
+	success, err := o.client.DeleteTeam(ctx, resourceId.GetResource())
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed to delete team: %w", err)
+	}
+	if !success {
+		return nil, fmt.Errorf("baton-linear: teamDelete returned success=false")
+	}
+	return nil, nil
+}
+
+func (o *teamResourceType) ResourceActions(ctx context.Context, registry actions.ActionRegistry) error {
+	return registry.Register(ctx, unarchiveTeamActionSchema, o.unarchiveTeam)
+}
+
+func (o *teamResourceType) unarchiveTeam(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+	resourceId, err := actions.RequireResourceIDArg(args, "resource_id")
+	if err != nil {
+		return nil, nil, err
+	}
+	if resourceId.GetResourceType() != resourceTypeTeam.Id {
+		return nil, nil, fmt.Errorf("baton-linear: non-team resource passed to unarchive team: %s", resourceId.GetResourceType())
+	}
+
+	success, err := o.client.UnarchiveTeam(ctx, resourceId.GetResource())
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-linear: failed to unarchive team: %w", err)
+	}
+	if !success {
+		return nil, nil, fmt.Errorf("baton-linear: teamUnarchive returned success=false")
+	}
+
+	return actions.NewReturnValues(true), nil, nil
+}
+
+func teamBuilder(client *linear.Client, skipPrivateTeams bool, skipAppUsers bool) *teamResourceType {
 	return &teamResourceType{
-		resourceType: resourceTypeTeam,
-		client:       client,
+		resourceType:     resourceTypeTeam,
+		client:           client,
+		skipPrivateTeams: skipPrivateTeams,
+		skipAppUsers:     skipAppUsers,
 	}
 }
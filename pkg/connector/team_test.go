@@ -0,0 +1,564 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/conductorone/baton-linear/pkg/linear"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newTestTeamBuilder(t *testing.T, handler http.HandlerFunc) *teamResourceType {
+	return teamBuilder(newTestClient(t, handler), false, false)
+}
+
+// teamTestServer answers team and per-user membership lookups from the given
+// membership nodes, which all belong to team-1. Mutations are recorded in
+// calls; new memberships are created with the ID m-new.
+func teamTestServer(t *testing.T, membershipsJSON string, calls *[]string) http.HandlerFunc {
+	var memberships []map[string]interface{}
+	if err := json.Unmarshal([]byte(membershipsJSON), &memberships); err != nil {
+		t.Fatalf("invalid memberships: %v", err)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = decodeJSON(t, r, &req)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(req.Query, "query Team("):
+			_, _ = w.Write([]byte(`{"data":{"team":{"id":"team-1","memberships":{"nodes":` + membershipsJSON + `,"pageInfo":{"hasNextPage":false}}}}}`))
+		case strings.Contains(req.Query, "query UserTeamMemberships("):
+			nodes := []map[string]interface{}{}
+			for _, m := range memberships {
+				if m["user"].(map[string]interface{})["id"] == req.Variables["userId"] {
+					m["team"] = map[string]string{"id": "team-1"}
+					nodes = append(nodes, m)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"user": map[string]interface{}{"teamMemberships": map[string]interface{}{"nodes": nodes}}},
+			})
+		case strings.Contains(req.Query, "teamMembershipUpdate("):
+			input := req.Variables["input"].(map[string]interface{})
+			*calls = append(*calls, fmt.Sprintf("update %s owner=%v", req.Variables["id"], input["owner"]))
+			_, _ = w.Write([]byte(`{"data":{"teamMembershipUpdate":{"success":true}}}`))
+		case strings.Contains(req.Query, "teamMembershipCreate("):
+			*calls = append(*calls, "create")
+			_, _ = w.Write([]byte(`{"data":{"teamMembershipCreate":{"success":true,"teamMembership":{"id":"m-new"}}}}`))
+		case strings.Contains(req.Query, "teamMembershipDelete("):
+			*calls = append(*calls, fmt.Sprintf("delete %s", req.Variables["teamMembershipDeleteId"]))
+			_, _ = w.Write([]byte(`{"data":{"teamMembershipDelete":{"success":true}}}`))
+		default:
+			t.Errorf("unexpected query: %s", req.Query)
+		}
+	}
+}
+
+func testTeamResource(t *testing.T) *v2.Resource {
+	t.Helper()
+	tr, err := teamResource(&linear.Team{ID: "team-1", Name: "Team"}, nil)
+	if err != nil {
+		t.Fatalf("teamResource: %v", err)
+	}
+	return tr
+}
+
+func TestTeamGrants_EmitsOwnerGrants(t *testing.T) {
+	var calls []string
+	tb := newTestTeamBuilder(t, teamTestServer(t, `[
+		{"id":"m1","owner":true,"user":{"id":"u1"}},
+		{"id":"m2","user":{"id":"u2"}}
+	]`, &calls))
+
+	grants, _, _, err := tb.Grants(context.Background(), testTeamResource(t), &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var owners, members []string
+	for _, g := range grants {
+		switch entitlementSlug(g.Entitlement) {
+		case ownerEntitlement:
+			owners = append(owners, g.Principal.Id.Resource)
+		case memberEntitlement:
+			members = append(members, g.Principal.Id.Resource)
+		}
+	}
+	if strings.Join(members, ",") != "u1,u2" {
+		t.Errorf("member grants: want u1,u2 got %v", members)
+	}
+	if strings.Join(owners, ",") != "u1" {
+		t.Errorf("owner grants: want u1 got %v", owners)
+	}
+}
+
+func TestTeamGrantMember(t *testing.T) {
+	tests := []struct {
+		name        string
+		memberships string
+		wantID      string
+		wantExists  bool
+	}{
+		{name: "new member", memberships: `[{"id":"m2","user":{"id":"u2"}}]`, wantID: "m-new"},
+		{name: "already a member", memberships: `[{"id":"m1","user":{"id":"u1"}}]`, wantID: "m1", wantExists: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			tb := newTestTeamBuilder(t, teamTestServer(t, tt.memberships, &calls))
+			en := &v2.Entitlement{Resource: testTeamResource(t), Slug: memberEntitlement}
+
+			grants, annos, err := tb.Grant(context.Background(), testUserPrincipal("u1"), en)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := annos.Contains(&v2.GrantAlreadyExists{}); got != tt.wantExists {
+				t.Errorf("GrantAlreadyExists: want %v got %v", tt.wantExists, got)
+			}
+			if len(grants) != 1 {
+				t.Fatalf("expected a single grant, got %d", len(grants))
+			}
+			if got := grantMembershipID(grants[0]); got != tt.wantID {
+				t.Errorf("membership_id: want %s got %s", tt.wantID, got)
+			}
+			if grants[0].Principal.Id.Resource != "u1" {
+				t.Errorf("expected grant to u1, got %s", grants[0].Principal.Id.Resource)
+			}
+		})
+	}
+}
+
+func TestTeamGrantOwner(t *testing.T) {
+	tests := []struct {
+		name        string
+		memberships string
+		wantCalls   string
+		wantExists  bool
+		wantErr     bool
+	}{
+		{name: "member promoted", memberships: `[{"id":"m1","user":{"id":"u1"}}]`, wantCalls: "update m1 owner=true"},
+		{name: "already owner", memberships: `[{"id":"m1","owner":true,"user":{"id":"u1"}}]`, wantExists: true},
+		{name: "not a member", memberships: `[{"id":"m2","user":{"id":"u2"}}]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			tb := newTestTeamBuilder(t, teamTestServer(t, tt.memberships, &calls))
+			en := &v2.Entitlement{Resource: testTeamResource(t), Slug: ownerEntitlement}
+
+			grants, annos, err := tb.Grant(context.Background(), testUserPrincipal("u1"), en)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := annos.Contains(&v2.GrantAlreadyExists{}); got != tt.wantExists {
+				t.Errorf("GrantAlreadyExists: want %v got %v", tt.wantExists, got)
+			}
+			if got := strings.Join(calls, ","); got != tt.wantCalls {
+				t.Errorf("calls: want %q got %q", tt.wantCalls, got)
+			}
+			if len(grants) != 1 || grantMembershipID(grants[0]) != "m1" {
+				t.Errorf("expected the owner grant for membership m1, got %v", grants)
+			}
+		})
+	}
+}
+
+func TestTeamRevokeOwner(t *testing.T) {
+	tests := []struct {
+		name        string
+		memberships string
+		wantCalls   string
+		wantRevoked bool
+	}{
+		{name: "owner demoted", memberships: `[{"id":"m1","owner":true,"user":{"id":"u1"}}]`, wantCalls: "update m1 owner=false"},
+		{name: "not an owner", memberships: `[{"id":"m1","user":{"id":"u1"}}]`, wantRevoked: true},
+		{name: "not a member", memberships: `[]`, wantRevoked: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			tb := newTestTeamBuilder(t, teamTestServer(t, tt.memberships, &calls))
+			g := grant.NewGrant(testTeamResource(t), ownerEntitlement, testUserPrincipal("u1").Id)
+
+			annos, err := tb.Revoke(context.Background(), g)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := annos.Contains(&v2.GrantAlreadyRevoked{}); got != tt.wantRevoked {
+				t.Errorf("GrantAlreadyRevoked: want %v got %v", tt.wantRevoked, got)
+			}
+			if got := strings.Join(calls, ","); got != tt.wantCalls {
+				t.Errorf("calls: want %q got %q", tt.wantCalls, got)
+			}
+		})
+	}
+}
+
+func TestTeamRevokeMember(t *testing.T) {
+	tests := []struct {
+		name        string
+		memberships string
+		metadata    map[string]interface{}
+		wantCalls   string
+		wantRevoked bool
+	}{
+		{
+			name:        "membership from metadata",
+			memberships: `[{"id":"m1","user":{"id":"u1"}}]`,
+			metadata:    map[string]interface{}{"membership_id": "m1"},
+			wantCalls:   "delete m1",
+		},
+		{
+			name:        "stale membership in metadata",
+			memberships: `[{"id":"m3","user":{"id":"u1"}}]`,
+			metadata:    map[string]interface{}{"membership_id": "m1"},
+			wantCalls:   "delete m3",
+		},
+		{
+			name:        "membership in metadata already gone",
+			memberships: `[]`,
+			metadata:    map[string]interface{}{"membership_id": "m1"},
+			wantRevoked: true,
+		},
+		{
+			name:        "membership looked up",
+			memberships: `[{"id":"m1","user":{"id":"u1"}},{"id":"m2","user":{"id":"u2"}}]`,
+			wantCalls:   "delete m1",
+		},
+		{
+			name:        "membership already gone",
+			memberships: `[{"id":"m2","user":{"id":"u2"}}]`,
+			wantRevoked: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			tb := newTestTeamBuilder(t, teamTestServer(t, tt.memberships, &calls))
+
+			var opts []grant.GrantOption
+			if tt.metadata != nil {
+				opts = append(opts, grant.WithGrantMetadata(tt.metadata))
+			}
+			g := grant.NewGrant(testTeamResource(t), memberEntitlement, testUserPrincipal("u1").Id, opts...)
+
+			annos, err := tb.Revoke(context.Background(), g)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := annos.Contains(&v2.GrantAlreadyRevoked{}); got != tt.wantRevoked {
+				t.Errorf("GrantAlreadyRevoked: want %v got %v", tt.wantRevoked, got)
+			}
+			if got := strings.Join(calls, ","); got != tt.wantCalls {
+				t.Errorf("calls: want %q got %q", tt.wantCalls, got)
+			}
+		})
+	}
+}
+
+func TestTeamCreate(t *testing.T) {
+	var seenInput map[string]interface{}
+	tb := newTestTeamBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = decodeJSON(t, r, &req)
+		if !strings.Contains(req.Query, "teamCreate(") {
+			t.Errorf("unexpected query: %s", req.Query)
+		}
+		seenInput = req.Variables["input"].(map[string]interface{})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"teamCreate":{"success":true,"team":{"id":"team-new","name":"Squad","key":"SQD"}}}}`))
+	})
+
+	profile, err := structpb.NewStruct(map[string]interface{}{teamKeyProfileKey: "sqd"})
+	if err != nil {
+		t.Fatalf("structpb: %v", err)
+	}
+	parent := &v2.ResourceId{ResourceType: resourceTypeOrg.Id, Resource: "org-1"}
+	in := &v2.Resource{DisplayName: "Squad", Description: "The new squad", Profile: profile, ParentResourceId: parent}
+
+	tr, _, err := tb.Create(context.Background(), in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tr.Id.Resource != "team-new" || tr.ParentResourceId.Resource != "org-1" {
+		t.Errorf("unexpected team resource: %v", tr)
+	}
+	if seenInput["name"] != "Squad" || seenInput["key"] != "SQD" || seenInput["description"] != "The new squad" {
+		t.Errorf("unexpected input: %v", seenInput)
+	}
+}
+
+func TestTeamCreate_MissingName(t *testing.T) {
+	tb := newTestTeamBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("API should not be called without a name")
+	})
+
+	if _, _, err := tb.Create(context.Background(), &v2.Resource{}); err == nil {
+		t.Fatal("expected error for missing name")
+	}
+}
+
+func TestTeamDeleteAndUnarchive(t *testing.T) {
+	var calls []string
+	tb := newTestTeamBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = decodeJSON(t, r, &req)
+		w.Header().Set("Content-Type", "application/json")
+		for _, name := range []string{"teamDelete", "teamUnarchive"} {
+			if strings.Contains(req.Query, name+"(") {
+				calls = append(calls, fmt.Sprintf("%s %s", name, req.Variables["id"]))
+				_, _ = w.Write([]byte(`{"data":{"` + name + `":{"success":true}}}`))
+				return
+			}
+		}
+		t.Errorf("unexpected query: %s", req.Query)
+	})
+
+	teamID := &v2.ResourceId{ResourceType: resourceTypeTeam.Id, Resource: "team-1"}
+	if _, err := tb.Delete(context.Background(), teamID); err != nil {
+		t.Fatalf("delete: unexpected error: %v", err)
+	}
+
+	args, err := structpb.NewStruct(map[string]interface{}{
+		"resource_id": map[string]interface{}{"resource_type_id": resourceTypeTeam.Id, "resource_id": "team-1"},
+	})
+	if err != nil {
+		t.Fatalf("structpb: %v", err)
+	}
+	if _, _, err := tb.unarchiveTeam(context.Background(), args); err != nil {
+		t.Fatalf("unarchive: unexpected error: %v", err)
+	}
+
+	if got := strings.Join(calls, ","); got != "teamDelete team-1,teamUnarchive team-1" {
+		t.Errorf("unexpected calls: %s", got)
+	}
+}
+
+func TestTeamList_PrivateTeams(t *testing.T) {
+	for _, skip := range []bool{false, true} {
+		tb := teamBuilder(newTestClient(t, serveFixtures(t, map[string]string{
+			"query Teams(": `{"data":{"teams":{"nodes":[
+				{"id":"team-open","name":"Open"},
+				{"id":"team-private","name":"Security","private":true}
+			],"pageInfo":{"hasNextPage":false}}}}`,
+		})), skip, false)
+
+		orgID := &v2.ResourceId{ResourceType: resourceTypeOrg.Id, Resource: "org-1"}
+		teams, _, _, err := tb.List(context.Background(), orgID, &pagination.Token{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := 2
+		if skip {
+			want = 1
+		}
+		if len(teams) != want {
+			t.Fatalf("skip=%v: expected %d teams, got %d", skip, want, len(teams))
+		}
+		if !skip && !isPrivateTeam(teams[1]) {
+			t.Errorf("expected team-private to be flagged private")
+		}
+		if isPrivateTeam(teams[0]) {
+			t.Errorf("expected team-open not to be flagged private")
+		}
+	}
+}
+
+func TestTeamGrants_FlagsPrivateTeams(t *testing.T) {
+	var calls []string
+	tb := newTestTeamBuilder(t, teamTestServer(t, `[{"id":"m1","user":{"id":"u1"}}]`, &calls))
+	team, err := teamResource(&linear.Team{ID: "team-1", Name: "Security", Private: true}, nil)
+	if err != nil {
+		t.Fatalf("teamResource: %v", err)
+	}
+
+	grants, _, _, err := tb.Grants(context.Background(), team, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grants) != 1 {
+		t.Fatalf("expected a single grant, got %d", len(grants))
+	}
+
+	metadata := &v2.GrantMetadata{}
+	annos := annotations.Annotations(grants[0].Annotations)
+	if ok, err := annos.Pick(metadata); err != nil || !ok {
+		t.Fatalf("grant has no metadata: %v", err)
+	}
+	if !metadata.GetMetadata().GetFields()["private_team"].GetBoolValue() {
+		t.Errorf("expected private_team metadata, got %v", metadata.GetMetadata())
+	}
+}
+
+func TestTeamList_SubTeams(t *testing.T) {
+	tb := newTestTeamBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = decodeJSON(t, r, &req)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(req.Query, "query SubTeams("):
+			if req.Variables["parentId"] != "team-eng" {
+				t.Errorf("unexpected parentId: %v", req.Variables["parentId"])
+			}
+			_, _ = w.Write([]byte(`{"data":{"teams":{"nodes":[
+				{"id":"team-web","name":"Web","parent":{"id":"team-eng"}}
+			],"pageInfo":{"hasNextPage":false}}}}`))
+		case strings.Contains(req.Query, "query Teams("):
+			_, _ = w.Write([]byte(`{"data":{"teams":{"nodes":[
+				{"id":"team-eng","name":"Engineering"},
+				{"id":"team-web","name":"Web","parent":{"id":"team-eng"}},
+				{"id":"team-ops","name":"Ops"}
+			],"pageInfo":{"hasNextPage":false}}}}`))
+		default:
+			t.Errorf("unexpected query: %s", req.Query)
+		}
+	})
+
+	listIDs := func(parentID *v2.ResourceId) string {
+		teams, _, _, err := tb.List(context.Background(), parentID, &pagination.Token{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var ids []string
+		for _, team := range teams {
+			if team.ParentResourceId.Resource != parentID.Resource {
+				t.Errorf("%s: expected parent %s, got %s", team.Id.Resource, parentID.Resource, team.ParentResourceId.Resource)
+			}
+			ids = append(ids, team.Id.Resource)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	if got := listIDs(&v2.ResourceId{ResourceType: resourceTypeOrg.Id, Resource: "org-1"}); got != "team-eng,team-ops" {
+		t.Errorf("org children: want team-eng,team-ops got %s", got)
+	}
+	if got := listIDs(&v2.ResourceId{ResourceType: resourceTypeTeam.Id, Resource: "team-eng"}); got != "team-web" {
+		t.Errorf("team-eng children: want team-web got %s", got)
+	}
+}
+
+func TestTeamList_SubTeamOfSkippedPrivateTeam(t *testing.T) {
+	tb := newTestTeamBuilder(t, serveFixtures(t, map[string]string{
+		"query Teams(": `{"data":{"teams":{"nodes":[
+			{"id":"team-sec","name":"Security","private":true},
+			{"id":"team-audit","name":"Audit","parent":{"id":"team-sec","private":true}}
+		],"pageInfo":{"hasNextPage":false}}}}`,
+	}))
+	tb.skipPrivateTeams = true
+
+	teams, _, _, err := tb.List(context.Background(), &v2.ResourceId{ResourceType: resourceTypeOrg.Id, Resource: "org-1"}, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(teams) != 1 || teams[0].Id.Resource != "team-audit" {
+		t.Fatalf("expected the public sub-team under the org, got %v", teams)
+	}
+}
+
+func TestTeamGrants_InheritsParentMembers(t *testing.T) {
+	tb := newTestTeamBuilder(t, serveFixtures(t, map[string]string{
+		"query Team(": `{"data":{"team":{"id":"team-web","parent":{"id":"team-eng"},"memberships":{"nodes":[
+			{"id":"m1","user":{"id":"u1"}}
+		],"pageInfo":{"hasNextPage":false}}}}}`,
+	}))
+	team, err := teamResource(&linear.Team{ID: "team-web", Name: "Web"}, &v2.ResourceId{ResourceType: resourceTypeTeam.Id, Resource: "team-eng"})
+	if err != nil {
+		t.Fatalf("teamResource: %v", err)
+	}
+
+	grants, _, _, err := tb.Grants(context.Background(), team, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grants) != 2 {
+		t.Fatalf("expected an inherited and a direct grant, got %d", len(grants))
+	}
+
+	inherited := grants[0]
+	if inherited.Principal.Id.ResourceType != resourceTypeTeam.Id || inherited.Principal.Id.Resource != "team-eng" {
+		t.Fatalf("expected a grant to the parent team, got %v", inherited.Principal.Id)
+	}
+	expandable := &v2.GrantExpandable{}
+	annos := annotations.Annotations(inherited.Annotations)
+	if ok, err := annos.Pick(expandable); err != nil || !ok {
+		t.Fatalf("expected GrantExpandable annotation: %v", err)
+	}
+	if ids := expandable.GetEntitlementIds(); len(ids) != 1 || ids[0] != "team:team-eng:member" {
+		t.Errorf("unexpected expandable entitlements: %v", ids)
+	}
+}
+
+func TestTeamGrants_ParentOnlyOnFirstPage(t *testing.T) {
+	tb := newTestTeamBuilder(t, serveFixtures(t, map[string]string{
+		"query Team(": `{"data":{"team":{"id":"team-web","parent":{"id":"team-eng"},"memberships":{"nodes":[
+			{"id":"m1","user":{"id":"u1"}}
+		],"pageInfo":{"hasNextPage":true,"endCursor":"c2"}}}}}`,
+	}))
+	team, err := teamResource(&linear.Team{ID: "team-web", Name: "Web"}, nil)
+	if err != nil {
+		t.Fatalf("teamResource: %v", err)
+	}
+
+	grants, next, _, err := tb.Grants(context.Background(), team, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grants) != 2 {
+		t.Fatalf("first page: expected the parent and a member grant, got %d", len(grants))
+	}
+
+	grants, _, _, err = tb.Grants(context.Background(), team, &pagination.Token{Token: next})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grants) != 1 {
+		t.Errorf("second page: expected only the member grant, got %d", len(grants))
+	}
+}
+
+func TestTeamGrants_NoParentGrantForSkippedPrivateParent(t *testing.T) {
+	tb := newTestTeamBuilder(t, serveFixtures(t, map[string]string{
+		"query Team(": `{"data":{"team":{"id":"team-audit","parent":{"id":"team-sec","private":true},"memberships":{"nodes":[],"pageInfo":{"hasNextPage":false}}}}}`,
+	}))
+	tb.skipPrivateTeams = true
+	team, err := teamResource(&linear.Team{ID: "team-audit", Name: "Audit"}, nil)
+	if err != nil {
+		t.Fatalf("teamResource: %v", err)
+	}
+
+	grants, _, _, err := tb.Grants(context.Background(), team, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grants) != 0 {
+		t.Errorf("expected no grant to the unsynced parent, got %v", grants)
+	}
+}
@@ -4,28 +4,63 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/conductorone/baton-linear/pkg/linear"
+	config "github.com/conductorone/baton-sdk/pb/c1/config/v1"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/actions"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
-	"github.com/conductorone/baton-sdk/pkg/types/grant"
 	sdkResource "github.com/conductorone/baton-sdk/pkg/types/resource"
-	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 var (
 	_ connectorbuilder.ResourceSyncer         = (*userResourceType)(nil)
 	_ connectorbuilder.AccountManagerLimited  = (*userResourceType)(nil)
 	_ connectorbuilder.ResourceDeleterLimited = (*userResourceType)(nil)
+	_ connectorbuilder.ResourceActionProvider = (*userResourceType)(nil)
 )
 
-const userRoleProfileKey = "user_role"
+const (
+	userRoleProfileKey  = "user_role"
+	userTeamsProfileKey = "teams"
+
+	enableUserActionName = "enable_user"
+)
+
+var enableUserActionSchema = &v2.BatonActionSchema{
+	Name:        enableUserActionName,
+	DisplayName: "Enable user",
+	Description: "Reactivate a suspended Linear user, restoring their access to the workspace.",
+	Arguments: []*config.Field{
+		{
+			Name:        "resource_id",
+			DisplayName: "User",
+			Description: "The user to reactivate.",
+			Field:       &config.Field_ResourceIdField{ResourceIdField: &config.ResourceIdField{}},
+			IsRequired:  true,
+		},
+	},
+	ReturnTypes: []*config.Field{
+		{
+			Name:        "success",
+			DisplayName: "Success",
+			Field:       &config.Field_BoolField{BoolField: &config.BoolField{}},
+		},
+	},
+	ActionType: []v2.ActionType{
+		v2.ActionType_ACTION_TYPE_ACCOUNT,
+		v2.ActionType_ACTION_TYPE_ACCOUNT_ENABLE,
+	},
+}
 
 type userResourceType struct {
 	resourceType *v2.ResourceType
 	client       *linear.Client
+	skipAppUsers bool
 }
 
 func (o *userResourceType) ResourceType(_ context.Context) *v2.ResourceType {
@@ -34,38 +69,42 @@ func (o *userResourceType) ResourceType(_ context.Context) *v2.ResourceType {
 
 // Create a new connector resource for a Linear user.
 func userResource(ctx context.Context, user *linear.User, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
-	names := strings.SplitN(user.Name, " ", 2)
-	var firstName, lastName string
-	switch len(names) {
-	case 1:
-		firstName = names[0]
-	case 2:
-		firstName = names[0]
-		lastName = names[1]
-	}
-
-	var userRole string
-	switch {
-	case user.Owner:
-		userRole = roleOwner
-	case user.Admin:
-		userRole = roleAdmin
-	case user.Guest:
-		userRole = roleGuest
-	default:
-		userRole = roleUser
-	}
-
 	profile := map[string]interface{}{
-		"first_name":       firstName,
-		"last_name":        lastName,
-		"login":            user.Email,
 		"user_id":          user.ID,
-		userRoleProfileKey: userRole,
+		userRoleProfileKey: userRole(user),
 	}
 
-	userTraitOptions := []sdkResource.UserTraitOption{
-		sdkResource.WithEmail(user.Email, true),
+	var userTraitOptions []sdkResource.UserTraitOption
+	if user.App {
+		// App actors and integration bots have no person behind them, so
+		// there is no name to split and no email to match identities on.
+		profile["login"] = user.DisplayName
+		userTraitOptions = append(userTraitOptions, sdkResource.WithAccountType(v2.UserTrait_ACCOUNT_TYPE_SERVICE))
+	} else {
+		names := strings.SplitN(user.Name, " ", 2)
+		var firstName, lastName string
+		switch len(names) {
+		case 1:
+			firstName = names[0]
+		case 2:
+			firstName = names[0]
+			lastName = names[1]
+		}
+
+		profile["first_name"] = firstName
+		profile["last_name"] = lastName
+		profile["login"] = user.Email
+		userTraitOptions = append(userTraitOptions,
+			sdkResource.WithEmail(user.Email, true),
+			sdkResource.WithAccountType(v2.UserTrait_ACCOUNT_TYPE_HUMAN),
+		)
+	}
+
+	status := v2.Status_RESOURCE_STATUS_ENABLED
+	var statusDetails string
+	if !user.Active {
+		status = v2.Status_RESOURCE_STATUS_DISABLED
+		statusDetails = "suspended"
 	}
 
 	ret, err := sdkResource.NewUserResource(
@@ -74,7 +113,7 @@ func userResource(ctx context.Context, user *linear.User, parentResourceID *v2.R
 		user.ID,
 		userTraitOptions,
 		sdkResource.WithResourceProfile(profile),
-		sdkResource.WithResourceStatus(v2.Status_RESOURCE_STATUS_ENABLED, ""),
+		sdkResource.WithResourceStatus(status, statusDetails),
 		sdkResource.WithParentResourceID(parentResourceID),
 	)
 	if err != nil {
@@ -108,6 +147,10 @@ func (o *userResourceType) List(ctx context.Context, parentId *v2.ResourceId, to
 
 	var rv []*v2.Resource
 	for _, user := range users {
+		if user.App && o.skipAppUsers {
+			continue
+		}
+
 		userCopy := user
 		ur, err := userResource(ctx, &userCopy, parentId)
 		if err != nil {
@@ -123,21 +166,8 @@ func (o *userResourceType) Entitlements(_ context.Context, _ *v2.Resource, _ *pa
 	return nil, "", nil, nil
 }
 
-func (o *userResourceType) Grants(ctx context.Context, resource *v2.Resource, pt *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
-	var rv []*v2.Grant
-	userProfile := sdkResource.GetProfile(resource)
-	userRole, present := sdkResource.GetProfileStringValue(userProfile, userRoleProfileKey)
-	if !present {
-		return nil, "", nil, fmt.Errorf("list-grants: user role was not present on profile")
-	}
-	rr, err := roleResource(ctx, userRole, resource.ParentResourceId)
-	if err != nil {
-		return nil, "", nil, err
-	}
-	gr := grant.NewGrant(rr, membership, resource.Id)
-
-	rv = append(rv, gr)
-	return rv, "", nil, nil
+func (o *userResourceType) Grants(_ context.Context, _ *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
 }
 
 func (o *userResourceType) CreateAccountCapabilityDetails(_ context.Context) (*v2.CredentialDetailsAccountProvisioning, annotations.Annotations, error) {
@@ -151,7 +181,9 @@ func (o *userResourceType) CreateAccountCapabilityDetails(_ context.Context) (*v
 
 // CreateAccount provisions a new Linear user by sending a workspace invite. The
 // user only becomes a Linear User after they accept the invite, so this returns
-// an ActionRequiredResult — there is no resource yet.
+// an ActionRequiredResult — there is no resource yet. Emails that already
+// belong to a user or a pending invite are handled by inviteUser. Teams listed in the
+// profile are added to the invite so the user joins them on acceptance.
 func (o *userResourceType) CreateAccount(
 	ctx context.Context,
 	accountInfo *v2.AccountInfo,
@@ -164,16 +196,136 @@ func (o *userResourceType) CreateAccount(
 
 	role := accountRole(accountInfo)
 
-	inviteID, err := o.client.CreateOrganizationInvite(ctx, email, role, nil)
+	teamIDs, err := o.resolveTeamIDs(ctx, accountTeams(accountInfo))
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("baton-linear: failed to create organization invite: %w", err)
+		return nil, nil, nil, err
+	}
+
+	user, _, err := o.client.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("baton-linear: failed to look up user by email: %w", err)
+	}
+
+	result, err := inviteUser(ctx, o.client, user, email, role, teamIDs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return result, nil, nil, nil
+}
+
+// inviteUser brings email into the workspace without creating duplicates. An
+// existing user is reactivated if suspended and added to the requested teams,
+// keeping their current role, and a pending invite is sent again. Only when
+// none of those exist is a new invite created. user is the existing user with
+// that email, if any, as looked up by the caller.
+func inviteUser(ctx context.Context, client *linear.Client, user *linear.User, email string, role string, teamIDs []string) (connectorbuilder.CreateAccountResponse, error) {
+	if user != nil {
+		if !user.Active {
+			success, err := client.UnsuspendUser(ctx, user.ID)
+			if err != nil {
+				return nil, fmt.Errorf("baton-linear: failed to unsuspend user: %w", err)
+			}
+			if !success {
+				return nil, fmt.Errorf("baton-linear: userUnsuspend returned success=false")
+			}
+			user.Active = true
+		}
+
+		if err := addUserToTeams(ctx, client, user, teamIDs); err != nil {
+			return nil, err
+		}
+
+		var parentResourceID *v2.ResourceId
+		if user.Organization.ID != "" {
+			parentResourceID = &v2.ResourceId{ResourceType: resourceTypeOrg.Id, Resource: user.Organization.ID}
+		}
+		ur, err := userResource(ctx, user, parentResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &v2.CreateAccountResponse_SuccessResult{
+			Resource:              ur,
+			IsCreateAccountResult: true,
+		}, nil
+	}
+
+	invite, err := findPendingInvite(ctx, client, email)
+	if err != nil {
+		return nil, err
+	}
+	if invite != nil {
+		success, err := client.ResendOrganizationInvite(ctx, invite.ID)
+		if err != nil {
+			return nil, fmt.Errorf("baton-linear: failed to resend organization invite: %w", err)
+		}
+		if !success {
+			return nil, fmt.Errorf("baton-linear: resendOrganizationInvite returned success=false")
+		}
+
+		return &v2.CreateAccountResponse_ActionRequiredResult{
+			Resource:              nil,
+			Message:               fmt.Sprintf("Invitation to %s was already pending and has been sent again (invite ID: %s). The user must accept the invite to join the workspace.", email, invite.ID),
+			IsCreateAccountResult: true,
+		}, nil
+	}
+
+	inviteID, err := client.CreateOrganizationInvite(ctx, email, role, teamIDs)
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed to create organization invite: %w", err)
 	}
 
 	return &v2.CreateAccountResponse_ActionRequiredResult{
 		Resource:              nil,
 		Message:               fmt.Sprintf("Invitation sent to %s (invite ID: %s). The user must accept the invite to join the workspace.", email, inviteID),
 		IsCreateAccountResult: true,
-	}, nil, nil, nil
+	}, nil
+}
+
+// addUserToTeams adds an existing user to the teams requested for their
+// account. Teams they already belong to are left alone, and teams they belong
+// to but weren't asked for are kept.
+func addUserToTeams(ctx context.Context, client *linear.Client, user *linear.User, teamIDs []string) error {
+	for _, teamID := range teamIDs {
+		membership, err := client.GetTeamMembership(ctx, teamID, user.ID)
+		if err != nil {
+			return fmt.Errorf("baton-linear: failed looking up team membership: %w", err)
+		}
+		if membership != nil {
+			continue
+		}
+		if _, err := client.AddMemberToTeam(ctx, teamID, user.ID); err != nil {
+			return fmt.Errorf("baton-linear: failed adding user to team: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// findPendingInvite pages through the workspace invites looking for one sent
+// to email that hasn't been accepted and hasn't expired.
+func findPendingInvite(ctx context.Context, client *linear.Client, email string) (*linear.OrganizationInvite, error) {
+	var after string
+	for {
+		invites, nextToken, _, err := client.GetOrganizationInvites(ctx, linear.GetResourcesVars{First: resourcePageSize, After: after})
+		if err != nil {
+			return nil, fmt.Errorf("baton-linear: failed to list organization invites: %w", err)
+		}
+		for _, invite := range invites {
+			if !strings.EqualFold(invite.Email, email) || invite.AcceptedAt != nil {
+				continue
+			}
+			if invite.ExpiresAt != nil && invite.ExpiresAt.Before(time.Now()) {
+				continue
+			}
+			return &invite, nil
+		}
+		if nextToken == "" {
+			return nil, nil
+		}
+		after = nextToken
+	}
 }
 
 // Delete deprovisions a Linear user by suspending them. Linear does not delete
@@ -183,7 +335,15 @@ func (o *userResourceType) Delete(ctx context.Context, resourceId *v2.ResourceId
 		return nil, fmt.Errorf("baton-linear: non-user resource passed to user delete: %s", resourceId.GetResourceType())
 	}
 
-	success, err := o.client.SuspendUser(ctx, resourceId.GetResource())
+	user, _, err := o.client.GetUser(ctx, resourceId.GetResource())
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed to get user: %w", err)
+	}
+	if err := guardLastAdmin(ctx, o.client, &user); err != nil {
+		return nil, err
+	}
+
+	success, err := o.client.SuspendUser(ctx, user.ID)
 	if err != nil {
 		return nil, fmt.Errorf("baton-linear: failed to suspend user: %w", err)
 	}
@@ -193,6 +353,32 @@ func (o *userResourceType) Delete(ctx context.Context, resourceId *v2.ResourceId
 	return nil, nil
 }
 
+func (o *userResourceType) ResourceActions(ctx context.Context, registry actions.ActionRegistry) error {
+	return registry.Register(ctx, enableUserActionSchema, o.enableUser)
+}
+
+// enableUser reactivates a user suspended by Delete. Linear keeps suspended
+// users' memberships intact, so unsuspending restores their previous access.
+func (o *userResourceType) enableUser(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+	resourceId, err := actions.RequireResourceIDArg(args, "resource_id")
+	if err != nil {
+		return nil, nil, err
+	}
+	if resourceId.GetResourceType() != resourceTypeUser.Id {
+		return nil, nil, fmt.Errorf("baton-linear: non-user resource passed to enable user: %s", resourceId.GetResourceType())
+	}
+
+	success, err := o.client.UnsuspendUser(ctx, resourceId.GetResource())
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-linear: failed to unsuspend user: %w", err)
+	}
+	if !success {
+		return nil, nil, fmt.Errorf("baton-linear: userUnsuspend returned success=false")
+	}
+
+	return actions.NewReturnValues(true), nil, nil
+}
+
 // accountEmail extracts the invitee's email from AccountInfo, preferring the
 // primary email, then any email, then Login.
 func accountEmail(accountInfo *v2.AccountInfo) string {
@@ -213,7 +399,8 @@ func accountEmail(accountInfo *v2.AccountInfo) string {
 }
 
 // accountRole extracts the requested Linear role from the profile. Defaults to
-// "user". Valid Linear values are admin, guest, user (owner is granted manually).
+// "user". Valid Linear values are admin, guest, user. Invites can't make
+// owners; use the transfer_ownership action once the user has joined.
 func accountRole(accountInfo *v2.AccountInfo) string {
 	if accountInfo == nil {
 		return ""
@@ -235,22 +422,89 @@ func accountRole(accountInfo *v2.AccountInfo) string {
 	}
 }
 
-// userBuilder returns the user syncer. Users have no entitlements of their
-// own, so the user resource type always skips the entitlements pass. The only
-// grants users emit are role memberships, so when skipRoleGrants is true (the
-// role resource type is excluded from the sync) the grants pass is skipped
-// too — the role resources those grants target wouldn't exist in the sync.
-func userBuilder(client *linear.Client, skipRoleGrants bool) *userResourceType {
-	resourceType := proto.Clone(resourceTypeUser).(*v2.ResourceType)
-	userAnnos := annotations.Annotations(resourceType.GetAnnotations())
-	if skipRoleGrants {
-		userAnnos.Update(&v2.SkipEntitlementsAndGrants{})
-	} else {
-		userAnnos.Update(&v2.SkipEntitlements{})
+// accountTeams extracts the requested teams from the profile. Accepts either a
+// list of strings or a single comma-separated string.
+func accountTeams(accountInfo *v2.AccountInfo) []string {
+	if accountInfo == nil {
+		return nil
+	}
+	field, ok := accountInfo.GetProfile().GetFields()[userTeamsProfileKey]
+	if !ok || field == nil {
+		return nil
+	}
+
+	var values []string
+	switch v := field.GetKind().(type) {
+	case *structpb.Value_ListValue:
+		for _, item := range v.ListValue.GetValues() {
+			values = append(values, item.GetStringValue())
+		}
+	case *structpb.Value_StringValue:
+		values = strings.Split(v.StringValue, ",")
+	}
+
+	var rv []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			rv = append(rv, value)
+		}
+	}
+	return rv
+}
+
+// resolveTeamIDs maps the requested team IDs or keys to team IDs. Keys are
+// matched case-insensitively. Any entry that matches no team is an error, so
+// a typo doesn't silently invite someone into fewer teams than requested.
+func (o *userResourceType) resolveTeamIDs(ctx context.Context, requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return nil, nil
+	}
+
+	teamIDs := make(map[string]string)
+	var after string
+	for {
+		teams, nextToken, _, err := o.client.GetTeams(ctx, linear.GetResourcesVars{First: resourcePageSize, After: after})
+		if err != nil {
+			return nil, fmt.Errorf("baton-linear: failed to list teams: %w", err)
+		}
+		for _, team := range teams {
+			teamIDs[team.ID] = team.ID
+			teamIDs[strings.ToUpper(team.Key)] = team.ID
+		}
+		if nextToken == "" {
+			break
+		}
+		after = nextToken
+	}
+
+	var rv, unknown []string
+	seen := make(map[string]bool)
+	for _, team := range requested {
+		id, ok := teamIDs[team]
+		if !ok {
+			id, ok = teamIDs[strings.ToUpper(team)]
+		}
+		if !ok {
+			unknown = append(unknown, team)
+			continue
+		}
+		if !seen[id] {
+			seen[id] = true
+			rv = append(rv, id)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("baton-linear: unknown teams: %s", strings.Join(unknown, ", "))
 	}
-	resourceType.Annotations = userAnnos
+
+	return rv, nil
+}
+
+func userBuilder(client *linear.Client, skipAppUsers bool) *userResourceType {
 	return &userResourceType{
-		resourceType: resourceType,
+		resourceType: resourceTypeUser,
 		client:       client,
+		skipAppUsers: skipAppUsers,
 	}
 }
@@ -10,6 +10,10 @@ import (
 
 	"github.com/conductorone/baton-linear/pkg/linear"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	sdkResource "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -21,7 +25,8 @@ func decodeJSON(t *testing.T, r *http.Request, out interface{}) error {
 	return nil
 }
 
-func newTestUserBuilder(t *testing.T, handler http.HandlerFunc) *userResourceType {
+// newTestClient points a Linear client at a fake API served by handler.
+func newTestClient(t *testing.T, handler http.Handler) *linear.Client {
 	t.Helper()
 	server := httptest.NewServer(handler)
 	t.Cleanup(server.Close)
@@ -29,7 +34,102 @@ func newTestUserBuilder(t *testing.T, handler http.HandlerFunc) *userResourceTyp
 	if err != nil {
 		t.Fatalf("failed to create client: %v", err)
 	}
-	return userBuilder(client, false)
+	return client
+}
+
+// serveFixtures answers each GraphQL request with the response fixture whose
+// key, such as "query Webhooks(", appears in the query, and fails the test on
+// any other query.
+func serveFixtures(t *testing.T, fixtures map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query string `json:"query"`
+		}
+		_ = decodeJSON(t, r, &req)
+		for key, response := range fixtures {
+			if strings.Contains(req.Query, key) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(response))
+				return
+			}
+		}
+		t.Errorf("unexpected query: %s", req.Query)
+	}
+}
+
+func newTestUserBuilder(t *testing.T, handler http.HandlerFunc) *userResourceType {
+	return userBuilder(newTestClient(t, handler), false)
+}
+
+// fakeInviteServer answers the lookups made before inviting or suspending a
+// user. users and invites are the JSON nodes returned for the user-by-email
+// and invite queries, and user the JSON returned for a user lookup by ID.
+// Mutations are recorded in calls, and the input of any new invite in input.
+type fakeInviteServer struct {
+	t       *testing.T
+	users   string
+	invites string
+	user    string
+	// workspace is the full user listing, used to count admins.
+	workspace string
+	// memberships is the JSON nodes of the user's team memberships.
+	memberships string
+	// lookups counts the user-by-email queries.
+	lookups int
+	calls   []string
+	input   map[string]interface{}
+}
+
+func (f *fakeInviteServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	_ = decodeJSON(f.t, r, &req)
+	nodes := func(v string) string {
+		if v == "" {
+			return "[]"
+		}
+		return v
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case strings.Contains(req.Query, "query UserByEmail("):
+		f.lookups++
+		_, _ = w.Write([]byte(`{"data":{"users":{"nodes":` + nodes(f.users) + `}}}`))
+	case strings.Contains(req.Query, "query User("):
+		_, _ = w.Write([]byte(`{"data":{"user":` + f.user + `}}`))
+	case strings.Contains(req.Query, "query Users("):
+		_, _ = w.Write([]byte(`{"data":{"users":{"nodes":` + nodes(f.workspace) + `,"pageInfo":{"hasNextPage":false}}}}`))
+	case strings.Contains(req.Query, "query OrganizationInvites("):
+		_, _ = w.Write([]byte(`{"data":{"organizationInvites":{"nodes":` + nodes(f.invites) + `,"pageInfo":{"hasNextPage":false}}}}`))
+	case strings.Contains(req.Query, "query Teams("):
+		_, _ = w.Write([]byte(`{"data":{"teams":{"nodes":[{"id":"team-eng","key":"ENG"},{"id":"team-ops","key":"OPS"}],"pageInfo":{"hasNextPage":false}}}}`))
+	case strings.Contains(req.Query, "organizationInviteCreate("):
+		f.calls = append(f.calls, "organizationInviteCreate")
+		f.input = req.Variables["input"].(map[string]interface{})
+		_, _ = w.Write([]byte(`{"data":{"organizationInviteCreate":{"success":true,"organizationInvite":{"id":"inv-abc"}}}}`))
+	case strings.Contains(req.Query, "userSuspend("):
+		f.calls = append(f.calls, "userSuspend")
+		_, _ = w.Write([]byte(`{"data":{"userSuspend":{"success":true}}}`))
+	case strings.Contains(req.Query, "userUnsuspend("):
+		f.calls = append(f.calls, "userUnsuspend")
+		_, _ = w.Write([]byte(`{"data":{"userUnsuspend":{"success":true}}}`))
+	case strings.Contains(req.Query, "query UserTeamMemberships("):
+		_, _ = w.Write([]byte(`{"data":{"user":{"teamMemberships":{"nodes":` + nodes(f.memberships) + `,"pageInfo":{"hasNextPage":false}}}}}`))
+	case strings.Contains(req.Query, "teamMembershipCreate("):
+		f.calls = append(f.calls, "teamMembershipCreate "+req.Variables["input"].(map[string]interface{})["teamId"].(string))
+		_, _ = w.Write([]byte(`{"data":{"teamMembershipCreate":{"success":true,"teamMembership":{"id":"m-new"}}}}`))
+	case strings.Contains(req.Query, "userChangeRole("):
+		f.calls = append(f.calls, "userChangeRole "+req.Variables["role"].(string))
+		_, _ = w.Write([]byte(`{"data":{"userChangeRole":{"success":true}}}`))
+	case strings.Contains(req.Query, "resendOrganizationInvite("):
+		f.calls = append(f.calls, "resendOrganizationInvite")
+		_, _ = w.Write([]byte(`{"data":{"resendOrganizationInvite":{"success":true}}}`))
+	default:
+		f.t.Errorf("unexpected query: %s", req.Query)
+	}
 }
 
 func TestUserCreateAccount_MissingEmail(t *testing.T) {
@@ -46,15 +146,8 @@ func TestUserCreateAccount_MissingEmail(t *testing.T) {
 }
 
 func TestUserCreateAccount_FromPrimaryEmail(t *testing.T) {
-	var seenInput map[string]interface{}
-	ub := newTestUserBuilder(t, func(w http.ResponseWriter, r *http.Request) {
-		var req map[string]interface{}
-		_ = decodeJSON(t, r, &req)
-		vars := req["variables"].(map[string]interface{})
-		seenInput = vars["input"].(map[string]interface{})
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"data":{"organizationInviteCreate":{"success":true,"organizationInvite":{"id":"inv-abc"}}}}`))
-	})
+	server := &fakeInviteServer{t: t}
+	ub := newTestUserBuilder(t, server.ServeHTTP)
 
 	info := &v2.AccountInfo{
 		Emails: []*v2.AccountInfo_Email{
@@ -73,31 +166,24 @@ func TestUserCreateAccount_FromPrimaryEmail(t *testing.T) {
 	if !strings.Contains(action.Message, "inv-abc") {
 		t.Errorf("message should contain invite ID, got: %s", action.Message)
 	}
-	if seenInput["email"] != "primary@example.com" {
-		t.Errorf("expected primary email, got %v", seenInput["email"])
+	if server.input["email"] != "primary@example.com" {
+		t.Errorf("expected primary email, got %v", server.input["email"])
 	}
-	if _, has := seenInput["role"]; has {
-		t.Errorf("role should not be sent when profile omits user_role, got %v", seenInput["role"])
+	if _, has := server.input["role"]; has {
+		t.Errorf("role should not be sent when profile omits user_role, got %v", server.input["role"])
 	}
 }
 
 func TestUserCreateAccount_FallsBackToLogin(t *testing.T) {
-	var seenEmail interface{}
-	ub := newTestUserBuilder(t, func(w http.ResponseWriter, r *http.Request) {
-		var req map[string]interface{}
-		_ = decodeJSON(t, r, &req)
-		vars := req["variables"].(map[string]interface{})
-		seenEmail = vars["input"].(map[string]interface{})["email"]
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"data":{"organizationInviteCreate":{"success":true,"organizationInvite":{"id":"inv-1"}}}}`))
-	})
+	server := &fakeInviteServer{t: t}
+	ub := newTestUserBuilder(t, server.ServeHTTP)
 
 	info := &v2.AccountInfo{Login: "login@example.com"}
 	if _, _, _, err := ub.CreateAccount(context.Background(), info, nil); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if seenEmail != "login@example.com" {
-		t.Errorf("expected login fallback, got %v", seenEmail)
+	if server.input["email"] != "login@example.com" {
+		t.Errorf("expected login fallback, got %v", server.input["email"])
 	}
 }
 
@@ -115,15 +201,8 @@ func TestUserCreateAccount_RoleFromProfile(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.profileRole, func(t *testing.T) {
-			var seenRole interface{}
-			ub := newTestUserBuilder(t, func(w http.ResponseWriter, r *http.Request) {
-				var req map[string]interface{}
-				_ = decodeJSON(t, r, &req)
-				vars := req["variables"].(map[string]interface{})
-				seenRole = vars["input"].(map[string]interface{})["role"]
-				w.Header().Set("Content-Type", "application/json")
-				_, _ = w.Write([]byte(`{"data":{"organizationInviteCreate":{"success":true,"organizationInvite":{"id":"inv-1"}}}}`))
-			})
+			server := &fakeInviteServer{t: t}
+			ub := newTestUserBuilder(t, server.ServeHTTP)
 
 			profile, err := structpb.NewStruct(map[string]interface{}{
 				userRoleProfileKey: tt.profileRole,
@@ -138,23 +217,194 @@ func TestUserCreateAccount_RoleFromProfile(t *testing.T) {
 			if _, _, _, err := ub.CreateAccount(context.Background(), info, nil); err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
-			if seenRole != tt.wantRole {
+			if seenRole := server.input["role"]; seenRole != tt.wantRole {
 				t.Errorf("role: want %v got %v", tt.wantRole, seenRole)
 			}
 		})
 	}
 }
 
+func TestUserCreateAccount_TeamsFromProfile(t *testing.T) {
+	tests := []struct {
+		name      string
+		teams     interface{}
+		wantTeams string
+	}{
+		{"ids", []interface{}{"team-eng", "team-ops"}, "team-eng,team-ops"},
+		{"keys", []interface{}{"eng", "OPS"}, "team-eng,team-ops"},
+		{"comma separated", "ENG, team-ops", "team-eng,team-ops"},
+		{"duplicates", []interface{}{"ENG", "team-eng"}, "team-eng"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &fakeInviteServer{t: t}
+			ub := newTestUserBuilder(t, server.ServeHTTP)
+
+			profile, err := structpb.NewStruct(map[string]interface{}{userTeamsProfileKey: tt.teams})
+			if err != nil {
+				t.Fatalf("structpb: %v", err)
+			}
+			info := &v2.AccountInfo{
+				Emails:  []*v2.AccountInfo_Email{{Address: "x@example.com", IsPrimary: true}},
+				Profile: profile,
+			}
+			if _, _, _, err := ub.CreateAccount(context.Background(), info, nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []string
+			for _, id := range server.input["teamIds"].([]interface{}) {
+				got = append(got, id.(string))
+			}
+			if strings.Join(got, ",") != tt.wantTeams {
+				t.Errorf("teams: want %s got %v", tt.wantTeams, got)
+			}
+		})
+	}
+}
+
+func TestUserCreateAccount_UnknownTeam(t *testing.T) {
+	server := &fakeInviteServer{t: t}
+	ub := newTestUserBuilder(t, server.ServeHTTP)
+
+	profile, err := structpb.NewStruct(map[string]interface{}{userTeamsProfileKey: []interface{}{"ENG", "DESIGN"}})
+	if err != nil {
+		t.Fatalf("structpb: %v", err)
+	}
+	info := &v2.AccountInfo{
+		Emails:  []*v2.AccountInfo_Email{{Address: "x@example.com", IsPrimary: true}},
+		Profile: profile,
+	}
+	_, _, _, err = ub.CreateAccount(context.Background(), info, nil)
+	if err == nil || !strings.Contains(err.Error(), "DESIGN") {
+		t.Fatalf("expected unknown team error naming DESIGN, got %v", err)
+	}
+	if len(server.calls) != 0 {
+		t.Errorf("invite should not be sent, got %v", server.calls)
+	}
+}
+
+func TestUserCreateAccount_ExistingEmail(t *testing.T) {
+	tests := []struct {
+		name        string
+		users       string
+		invites     string
+		wantCalls   string
+		wantSuccess bool
+	}{
+		{
+			name:        "active user",
+			users:       `[{"id":"u1","email":"x@example.com","active":true,"organization":{"id":"org-1"}}]`,
+			wantSuccess: true,
+		},
+		{
+			name:        "suspended user",
+			users:       `[{"id":"u1","email":"x@example.com","active":false,"organization":{"id":"org-1"}}]`,
+			wantCalls:   "userUnsuspend",
+			wantSuccess: true,
+		},
+		{
+			name:      "pending invite",
+			invites:   `[{"id":"inv-1","email":"X@example.com","createdAt":"2024-01-01T00:00:00Z","expiresAt":"2999-01-01T00:00:00Z"}]`,
+			wantCalls: "resendOrganizationInvite",
+		},
+		{
+			name:      "expired invite",
+			invites:   `[{"id":"inv-1","email":"x@example.com","createdAt":"2024-01-01T00:00:00Z","expiresAt":"2024-01-08T00:00:00Z"}]`,
+			wantCalls: "organizationInviteCreate",
+		},
+		{
+			name:      "accepted invite",
+			invites:   `[{"id":"inv-1","email":"x@example.com","createdAt":"2024-01-01T00:00:00Z","acceptedAt":"2024-01-02T00:00:00Z"}]`,
+			wantCalls: "organizationInviteCreate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &fakeInviteServer{t: t, users: tt.users, invites: tt.invites}
+			ub := newTestUserBuilder(t, server.ServeHTTP)
+			info := &v2.AccountInfo{Emails: []*v2.AccountInfo_Email{{Address: "x@example.com", IsPrimary: true}}}
+
+			resp, _, _, err := ub.CreateAccount(context.Background(), info, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := strings.Join(server.calls, ","); got != tt.wantCalls {
+				t.Errorf("calls: want %q got %q", tt.wantCalls, got)
+			}
+
+			success, ok := resp.(*v2.CreateAccountResponse_SuccessResult)
+			if ok != tt.wantSuccess {
+				t.Fatalf("expected success result %v, got %T", tt.wantSuccess, resp)
+			}
+			if ok {
+				if success.Resource.Id.Resource != "u1" {
+					t.Errorf("expected existing user u1, got %s", success.Resource.Id.Resource)
+				}
+				if got := success.Resource.GetStatus().GetStatus(); got != v2.Status_RESOURCE_STATUS_ENABLED {
+					t.Errorf("expected enabled user, got %v", got)
+				}
+			}
+		})
+	}
+}
+
+func TestUserCreateAccount_ExistingUserKeepsRoleAndGetsTeams(t *testing.T) {
+	tests := []struct {
+		name      string
+		users     string
+		wantCalls string
+	}{
+		{
+			name:      "active admin",
+			users:     `[{"id":"u1","email":"x@example.com","active":true,"admin":true}]`,
+			wantCalls: "teamMembershipCreate team-ops",
+		},
+		{
+			name:      "suspended user",
+			users:     `[{"id":"u1","email":"x@example.com","active":false}]`,
+			wantCalls: "userUnsuspend,teamMembershipCreate team-ops",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &fakeInviteServer{
+				t:           t,
+				users:       tt.users,
+				memberships: `[{"id":"m1","user":{"id":"u1"},"team":{"id":"team-eng"}}]`,
+			}
+			ub := newTestUserBuilder(t, server.ServeHTTP)
+
+			profile, err := structpb.NewStruct(map[string]interface{}{
+				userRoleProfileKey:  "guest",
+				userTeamsProfileKey: []interface{}{"team-eng", "team-ops"},
+			})
+			if err != nil {
+				t.Fatalf("structpb: %v", err)
+			}
+			info := &v2.AccountInfo{
+				Emails:  []*v2.AccountInfo_Email{{Address: "x@example.com", IsPrimary: true}},
+				Profile: profile,
+			}
+
+			_, _, _, err = ub.CreateAccount(context.Background(), info, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := strings.Join(server.calls, ","); got != tt.wantCalls {
+				t.Errorf("calls: want %q got %q", tt.wantCalls, got)
+			}
+		})
+	}
+}
+
 func TestUserDelete_Success(t *testing.T) {
-	var seenID interface{}
-	ub := newTestUserBuilder(t, func(w http.ResponseWriter, r *http.Request) {
-		var req map[string]interface{}
-		_ = decodeJSON(t, r, &req)
-		vars := req["variables"].(map[string]interface{})
-		seenID = vars["id"]
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"data":{"userSuspend":{"success":true}}}`))
-	})
+	server := &fakeInviteServer{
+		t:         t,
+		user:      `{"id":"user-xyz","active":true,"admin":true}`,
+		workspace: `[{"id":"user-xyz","active":true,"admin":true},{"id":"user-abc","active":true,"owner":true}]`,
+	}
+	ub := newTestUserBuilder(t, server.ServeHTTP)
 
 	_, err := ub.Delete(context.Background(), &v2.ResourceId{
 		ResourceType: resourceTypeUser.Id,
@@ -163,8 +413,28 @@ func TestUserDelete_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if seenID != "user-xyz" {
-		t.Errorf("user id: want user-xyz got %v", seenID)
+	if strings.Join(server.calls, ",") != "userSuspend" {
+		t.Errorf("expected user to be suspended, got %v", server.calls)
+	}
+}
+
+func TestUserDelete_LastAdmin(t *testing.T) {
+	server := &fakeInviteServer{
+		t:         t,
+		user:      `{"id":"user-xyz","active":true,"admin":true}`,
+		workspace: `[{"id":"user-xyz","active":true,"admin":true},{"id":"user-abc","active":false,"owner":true}]`,
+	}
+	ub := newTestUserBuilder(t, server.ServeHTTP)
+
+	_, err := ub.Delete(context.Background(), &v2.ResourceId{
+		ResourceType: resourceTypeUser.Id,
+		Resource:     "user-xyz",
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if len(server.calls) != 0 {
+		t.Errorf("expected no mutations, got %v", server.calls)
 	}
 }
 
@@ -183,7 +453,13 @@ func TestUserDelete_WrongResourceType(t *testing.T) {
 
 func TestUserDelete_SuccessFalse(t *testing.T) {
 	ub := newTestUserBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = decodeJSON(t, r, &req)
 		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(req["query"].(string), "query User(") {
+			_, _ = w.Write([]byte(`{"data":{"user":{"id":"user-xyz","active":true}}}`))
+			return
+		}
 		_, _ = w.Write([]byte(`{"data":{"userSuspend":{"success":false}}}`))
 	})
 	_, err := ub.Delete(context.Background(), &v2.ResourceId{
@@ -194,3 +470,102 @@ func TestUserDelete_SuccessFalse(t *testing.T) {
 		t.Fatal("expected error when userSuspend returns success=false")
 	}
 }
+
+func TestUserResource_StatusFromActive(t *testing.T) {
+	tests := []struct {
+		active bool
+		want   v2.Status_ResourceStatus
+	}{
+		{true, v2.Status_RESOURCE_STATUS_ENABLED},
+		{false, v2.Status_RESOURCE_STATUS_DISABLED},
+	}
+	for _, tt := range tests {
+		ur, err := userResource(context.Background(), &linear.User{ID: "u1", Name: "Jane Doe", Active: tt.active}, nil)
+		if err != nil {
+			t.Fatalf("userResource: %v", err)
+		}
+		if got := ur.GetStatus().GetStatus(); got != tt.want {
+			t.Errorf("active=%v: want status %v got %v", tt.active, tt.want, got)
+		}
+	}
+}
+
+func TestUserResource_AppUserIsServiceAccount(t *testing.T) {
+	ur, err := userResource(context.Background(), &linear.User{ID: "app-1", Name: "GitHub Bot", DisplayName: "github", Email: "bot@linear.app", App: true, Active: true}, nil)
+	if err != nil {
+		t.Fatalf("userResource: %v", err)
+	}
+	userTrait, err := sdkResource.GetUserTrait(ur)
+	if err != nil {
+		t.Fatalf("GetUserTrait: %v", err)
+	}
+	if got := userTrait.GetAccountType(); got != v2.UserTrait_ACCOUNT_TYPE_SERVICE {
+		t.Errorf("expected service account, got %v", got)
+	}
+	if len(userTrait.GetEmails()) != 0 {
+		t.Errorf("expected no emails for an app user, got %v", userTrait.GetEmails())
+	}
+	if _, ok := userTrait.GetProfile().GetFields()["first_name"]; ok {
+		t.Error("expected no first name for an app user")
+	}
+}
+
+func TestUserList_SkipAppUsers(t *testing.T) {
+	fixtures := map[string]string{
+		"query Users(": `{"data":{"users":{"nodes":[
+			{"id":"u1","name":"Ada Lovelace","email":"ada@example.com","active":true},
+			{"id":"app-1","name":"GitHub","displayName":"github","app":true,"active":true}
+		],"pageInfo":{"hasNextPage":false}}}}`,
+	}
+	orgID := &v2.ResourceId{ResourceType: resourceTypeOrg.Id, Resource: "org-1"}
+
+	for _, tt := range []struct {
+		skip bool
+		want int
+	}{{false, 2}, {true, 1}} {
+		client := newTestClient(t, serveFixtures(t, fixtures))
+		resources, _, _, err := userBuilder(client, tt.skip).List(context.Background(), orgID, &pagination.Token{})
+		if err != nil {
+			t.Fatalf("skip=%v: unexpected error: %v", tt.skip, err)
+		}
+		if len(resources) != tt.want {
+			t.Errorf("skip=%v: expected %d users, got %d", tt.skip, tt.want, len(resources))
+		}
+	}
+}
+
+func TestUserEnableUser(t *testing.T) {
+	var seenQuery string
+	var seenID interface{}
+	ub := newTestUserBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = decodeJSON(t, r, &req)
+		seenQuery = req["query"].(string)
+		seenID = req["variables"].(map[string]interface{})["id"]
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"userUnsuspend":{"success":true}}}`))
+	})
+
+	args, err := structpb.NewStruct(map[string]interface{}{
+		"resource_id": map[string]interface{}{
+			"resource_type_id": resourceTypeUser.Id,
+			"resource_id":      "user-xyz",
+		},
+	})
+	if err != nil {
+		t.Fatalf("structpb: %v", err)
+	}
+	rv, _, err := ub.enableUser(context.Background(), args)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !rv.GetFields()["success"].GetBoolValue() {
+		t.Errorf("expected success=true, got %v", rv)
+	}
+	if !strings.Contains(seenQuery, "userUnsuspend") {
+		t.Errorf("expected userUnsuspend mutation, got %s", seenQuery)
+	}
+	if seenID != "user-xyz" {
+		t.Errorf("user id: want user-xyz got %v", seenID)
+	}
+}
@@ -0,0 +1,232 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/conductorone/baton-linear/pkg/linear"
+	config "github.com/conductorone/baton-sdk/pb/c1/config/v1"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/actions"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var (
+	_ connectorbuilder.ResourceSyncer         = (*webhookResourceType)(nil)
+	_ connectorbuilder.ResourceDeleterLimited = (*webhookResourceType)(nil)
+	_ connectorbuilder.ResourceActionProvider = (*webhookResourceType)(nil)
+)
+
+const (
+	webhookCreator = "creator"
+
+	disableWebhookActionName = "disable_webhook"
+)
+
+var disableWebhookActionSchema = &v2.BatonActionSchema{
+	Name:        disableWebhookActionName,
+	DisplayName: "Disable webhook",
+	Description: "Stop a Linear webhook from delivering events, keeping its configuration.",
+	Arguments: []*config.Field{
+		{
+			Name:        "resource_id",
+			DisplayName: "Webhook",
+			Description: "The webhook to disable.",
+			Field:       &config.Field_ResourceIdField{ResourceIdField: &config.ResourceIdField{}},
+			IsRequired:  true,
+		},
+	},
+	ReturnTypes: []*config.Field{
+		{
+			Name:        "success",
+			DisplayName: "Success",
+			Field:       &config.Field_BoolField{BoolField: &config.BoolField{}},
+		},
+	},
+	ActionType: []v2.ActionType{
+		v2.ActionType_ACTION_TYPE_RESOURCE_DISABLE,
+	},
+}
+
+type webhookResourceType struct {
+	resourceType     *v2.ResourceType
+	client           *linear.Client
+	skipPrivateTeams bool
+	skipAppUsers     bool
+}
+
+func (o *webhookResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return o.resourceType
+}
+
+// Create a new connector resource for a Linear webhook. Only the host of the
+// webhook URL is kept, since the path and query often embed a secret. The
+// creator is kept in the profile for Grants.
+func webhookResource(webhook *linear.Webhook, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	host := webhook.URL
+	if u, err := url.Parse(webhook.URL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	profile := map[string]interface{}{
+		"webhook_id":       webhook.ID,
+		"url_host":         host,
+		"enabled":          webhook.Enabled,
+		"resource_types":   strings.Join(webhook.ResourceTypes, ","),
+		"all_public_teams": webhook.AllPublicTeams,
+		"created_at":       webhook.CreatedAt.Format(time.RFC3339),
+	}
+	if webhook.Team != nil {
+		profile["team_id"] = webhook.Team.ID
+		profile["team_name"] = webhook.Team.Name
+		profile[teamKeyProfileKey] = webhook.Team.Key
+	}
+	if webhook.Creator != nil {
+		profile["creator_id"] = webhook.Creator.ID
+	}
+
+	name := webhook.Label
+	if name == "" {
+		name = host
+	}
+
+	status := v2.Status_RESOURCE_STATUS_ENABLED
+	if !webhook.Enabled {
+		status = v2.Status_RESOURCE_STATUS_DISABLED
+	}
+
+	ret, err := rs.NewResource(
+		name,
+		resourceTypeWebhook,
+		webhook.ID,
+		rs.WithDescription(fmt.Sprintf("Webhook delivering %s events to %s", strings.Join(webhook.ResourceTypes, ", "), host)),
+		rs.WithResourceProfile(profile),
+		rs.WithResourceStatus(status, ""),
+		rs.WithParentResourceID(parentResourceID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (o *webhookResourceType) List(ctx context.Context, parentId *v2.ResourceId, token *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	var annotations annotations.Annotations
+	if parentId == nil {
+		return nil, "", nil, nil
+	}
+
+	bag, err := parsePageToken(token.Token, &v2.ResourceId{ResourceType: resourceTypeWebhook.Id})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	webhooks, nextToken, rlData, err := o.client.GetWebhooks(ctx, linear.GetResourcesVars{First: resourcePageSize, After: bag.PageToken()})
+	annotations.WithRateLimiting(rlData)
+	if err != nil {
+		return nil, "", annotations, fmt.Errorf("linear-connector: failed to list webhooks: %w", err)
+	}
+
+	pageToken, err := bag.NextToken(nextToken)
+	if err != nil {
+		return nil, "", annotations, err
+	}
+
+	var rv []*v2.Resource
+	for _, webhook := range webhooks {
+		webhookCopy := webhook
+		webhookCopy.Team, webhookCopy.Creator = syncedRefs(webhookCopy.Team, webhookCopy.Creator, o.skipPrivateTeams, o.skipAppUsers)
+		wr, err := webhookResource(&webhookCopy, parentId)
+		if err != nil {
+			return nil, "", annotations, err
+		}
+		rv = append(rv, wr)
+	}
+
+	return rv, pageToken, annotations, nil
+}
+
+func (o *webhookResourceType) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	options := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser),
+		ent.WithDescription(fmt.Sprintf("Created the %s Linear webhook", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s Webhook %s", resource.DisplayName, webhookCreator)),
+	}
+
+	return []*v2.Entitlement{ent.NewAssignmentEntitlement(resource, webhookCreator, options...)}, "", nil, nil
+}
+
+// Grants emits the user who created the webhook, as recorded on the resource
+// when it was listed.
+func (o *webhookResourceType) Grants(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	creatorID, ok := rs.GetProfileStringValue(rs.GetProfile(resource), "creator_id")
+	if !ok {
+		return nil, "", nil, nil
+	}
+
+	principalID, err := rs.NewResourceID(resourceTypeUser, creatorID)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return []*v2.Grant{grant.NewGrant(resource, webhookCreator, principalID)}, "", nil, nil
+}
+
+// Delete removes the webhook.
+func (o *webhookResourceType) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	if resourceId.GetResourceType() != resourceTypeWebhook.Id {
+		return nil, fmt.Errorf("baton-linear: non-webhook resource passed to webhook delete: %s", resourceId.GetResourceType())
+	}
+
+	success, err := o.client.DeleteWebhook(ctx, resourceId.GetResource())
+	if err != nil {
+		return nil, fmt.Errorf("baton-linear: failed to delete webhook: %w", err)
+	}
+	if !success {
+		return nil, fmt.Errorf("baton-linear: webhookDelete returned success=false")
+	}
+	return nil, nil
+}
+
+func (o *webhookResourceType) ResourceActions(ctx context.Context, registry actions.ActionRegistry) error {
+	return registry.Register(ctx, disableWebhookActionSchema, o.disableWebhook)
+}
+
+func (o *webhookResourceType) disableWebhook(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+	resourceId, err := actions.RequireResourceIDArg(args, "resource_id")
+	if err != nil {
+		return nil, nil, err
+	}
+	if resourceId.GetResourceType() != resourceTypeWebhook.Id {
+		return nil, nil, fmt.Errorf("baton-linear: non-webhook resource passed to disable webhook: %s", resourceId.GetResourceType())
+	}
+
+	success, err := o.client.DisableWebhook(ctx, resourceId.GetResource())
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-linear: failed to disable webhook: %w", err)
+	}
+	if !success {
+		return nil, nil, fmt.Errorf("baton-linear: webhookUpdate returned success=false")
+	}
+
+	return actions.NewReturnValues(true), nil, nil
+}
+
+func webhookBuilder(client *linear.Client, skipPrivateTeams bool, skipAppUsers bool) *webhookResourceType {
+	return &webhookResourceType{
+		resourceType:     resourceTypeWebhook,
+		client:           client,
+		skipPrivateTeams: skipPrivateTeams,
+		skipAppUsers:     skipAppUsers,
+	}
+}
@@ -0,0 +1,104 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/conductorone/baton-linear/pkg/linear"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newTestWebhookBuilder(t *testing.T, handler http.HandlerFunc) *webhookResourceType {
+	return webhookBuilder(newTestClient(t, handler), false, false)
+}
+
+func TestWebhookList(t *testing.T) {
+	wb := newTestWebhookBuilder(t, serveFixtures(t, map[string]string{
+		"query Webhooks(": `{"data":{"webhooks":{"nodes":[
+			{"id":"wh-1","label":"Sync","url":"https://hooks.example.com/linear?token=s3cret","enabled":true,"resourceTypes":["Issue","Comment"],"team":{"id":"team-1","name":"Engineering","key":"ENG"}},
+			{"id":"wh-2","url":"https://other.example.com/in","enabled":false,"resourceTypes":["Issue"],"allPublicTeams":true}
+		],"pageInfo":{"hasNextPage":false}}}}`,
+	}))
+
+	orgID := &v2.ResourceId{ResourceType: resourceTypeOrg.Id, Resource: "org-1"}
+	resources, _, _, err := wb.List(context.Background(), orgID, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 webhooks, got %d", len(resources))
+	}
+	if resources[0].DisplayName != "Sync" || resources[1].DisplayName != "other.example.com" {
+		t.Errorf("unexpected names: %q, %q", resources[0].DisplayName, resources[1].DisplayName)
+	}
+	if strings.Contains(resources[0].Description, "s3cret") {
+		t.Errorf("webhook URL query leaked into description: %q", resources[0].Description)
+	}
+	if got := resources[1].GetStatus().GetStatus(); got != v2.Status_RESOURCE_STATUS_DISABLED {
+		t.Errorf("disabled webhook: expected disabled status, got %v", got)
+	}
+}
+
+func TestWebhookGrants_Creator(t *testing.T) {
+	wb := newTestWebhookBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to Linear")
+		http.Error(w, "unexpected", http.StatusInternalServerError)
+	})
+
+	webhook, err := webhookResource(&linear.Webhook{ID: "wh-1", URL: "https://hooks.example.com", Creator: &linear.User{ID: "u1", Name: "Ada"}}, nil)
+	if err != nil {
+		t.Fatalf("webhookResource: %v", err)
+	}
+
+	grants, _, _, err := wb.Grants(context.Background(), webhook, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grants) != 1 || grants[0].Principal.Id.Resource != "u1" {
+		t.Fatalf("expected a single creator grant to u1, got %v", grants)
+	}
+}
+
+func TestWebhookDisableAndDelete(t *testing.T) {
+	var calls []string
+	wb := newTestWebhookBuilder(t, func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = decodeJSON(t, r, &req)
+		query := req["query"].(string)
+		vars := req["variables"].(map[string]interface{})
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(query, "webhookUpdate("):
+			if enabled := vars["input"].(map[string]interface{})["enabled"]; enabled != false {
+				t.Errorf("expected enabled=false, got %v", enabled)
+			}
+			calls = append(calls, "update "+vars["id"].(string))
+			_, _ = w.Write([]byte(`{"data":{"webhookUpdate":{"success":true}}}`))
+		case strings.Contains(query, "webhookDelete("):
+			calls = append(calls, "delete "+vars["id"].(string))
+			_, _ = w.Write([]byte(`{"data":{"webhookDelete":{"success":true}}}`))
+		default:
+			t.Errorf("unexpected query: %s", query)
+		}
+	})
+
+	args, err := structpb.NewStruct(map[string]interface{}{
+		"resource_id": map[string]interface{}{"resource_type_id": resourceTypeWebhook.Id, "resource_id": "wh-1"},
+	})
+	if err != nil {
+		t.Fatalf("failed to build args: %v", err)
+	}
+	if _, _, err := wb.disableWebhook(context.Background(), args); err != nil {
+		t.Fatalf("disable: unexpected error: %v", err)
+	}
+	if _, err := wb.Delete(context.Background(), &v2.ResourceId{ResourceType: resourceTypeWebhook.Id, Resource: "wh-1"}); err != nil {
+		t.Fatalf("delete: unexpected error: %v", err)
+	}
+	if strings.Join(calls, ",") != "update wh-1,delete wh-1" {
+		t.Errorf("expected the webhook disabled then deleted, got %v", calls)
+	}
+}
@@ -60,6 +60,24 @@ type GraphQLUsersResponse struct {
 	} `json:"data"`
 }
 
+type GraphQLUserResponse struct {
+	Data struct {
+		User User `json:"user"`
+	} `json:"data"`
+}
+
+type GraphQLOrganizationInvitesResponse struct {
+	Data struct {
+		OrganizationInvites OrganizationInvites `json:"organizationInvites"`
+	} `json:"data"`
+}
+
+type GraphQLAuthorizedApplicationsResponse struct {
+	Data struct {
+		WorkspaceAuthorizedApplications []AuthorizedApplication `json:"workspaceAuthorizedApplications"`
+	} `json:"data"`
+}
+
 type GraphQLTeamsResponse struct {
 	Data struct {
 		Teams Teams `json:"teams"`
@@ -72,6 +90,30 @@ type GraphQLProjectsResponse struct {
 	} `json:"data"`
 }
 
+type GraphQLInitiativesResponse struct {
+	Data struct {
+		Initiatives Initiatives `json:"initiatives"`
+	} `json:"data"`
+}
+
+type GraphQLInitiativeResponse struct {
+	Data struct {
+		Initiative Initiative `json:"initiative"`
+	} `json:"data"`
+}
+
+type GraphQLIntegrationsResponse struct {
+	Data struct {
+		Integrations Integrations `json:"integrations"`
+	} `json:"data"`
+}
+
+type GraphQLWebhooksResponse struct {
+	Data struct {
+		Webhooks Webhooks `json:"webhooks"`
+	} `json:"data"`
+}
+
 type GraphQLOrganizationResponse struct {
 	Data struct {
 		Organization Organization `json:"organization"`
@@ -120,7 +162,8 @@ type GraphQLIssueLabelsResponse struct {
 type GraphQLIssuesResponse struct {
 	Data struct {
 		Issues struct {
-			Nodes []Issue `json:"nodes"`
+			Nodes    []Issue  `json:"nodes"`
+			PageInfo PageInfo `json:"pageInfo"`
 		} `json:"issues"`
 	} `json:"data"`
 }
@@ -141,6 +184,25 @@ type GetTeamsVars struct {
 	First   int      `json:"first,omitempty"`
 }
 
+type GetSubTeamsVars struct {
+	ParentID string `json:"parentId"`
+	After    string `json:"after,omitempty"`
+	First    int    `json:"first,omitempty"`
+}
+
+type GetAssignedIssuesVars struct {
+	UserID         string         `json:"userId"`
+	ExcludedStates []WorkflowType `json:"excludedStates,omitempty"`
+	After          string         `json:"after,omitempty"`
+	First          int            `json:"first,omitempty"`
+}
+
+type GetInitiativeVars struct {
+	InitiativeId string `json:"initiativeId"`
+	After        string `json:"after,omitempty"`
+	First        int    `json:"first,omitempty"`
+}
+
 type GetProjectVars struct {
 	First      int    `json:"first,omitempty"`
 	UsersAfter string `json:"usersAfter,omitempty"`
@@ -164,13 +226,15 @@ type CreateIssuePayload struct {
 	FieldOptions map[string]interface{}
 }
 
-// GetUsers returns all users from Linear organization.
+// GetUsers returns all users from Linear organization, including suspended
+// users so they can be reported as disabled.
 func (c *Client) GetUsers(ctx context.Context, getResourceVars GetResourcesVars) ([]User, string, *v2.RateLimitDescription, error) {
 	query := `query Users($after: String, $first: Int) {
-			users(after: $after, first: $first) {
+			users(after: $after, first: $first, includeDisabled: true) {
 				nodes {
 					active
 					admin
+					app
 					displayName
 					email
 					guest
@@ -220,6 +284,11 @@ func (c *Client) GetTeams(ctx context.Context, getResourceVars GetResourcesVars)
 					name
 					key
 					description
+					private
+					parent {
+						id
+						private
+					}
 				}
 				pageInfo {
 					hasPreviousPage
@@ -248,6 +317,49 @@ func (c *Client) GetTeams(ctx context.Context, getResourceVars GetResourcesVars)
 	return res.Data.Teams.Nodes, "", rlData, nil
 }
 
+// GetSubTeams returns the teams directly under a parent team, filtered by
+// Linear so that walking the team tree doesn't page through every team.
+func (c *Client) GetSubTeams(ctx context.Context, getSubTeamsVars GetSubTeamsVars) ([]Team, string, *v2.RateLimitDescription, error) {
+	query := `query SubTeams($parentId: ID!, $after: String, $first: Int) {
+			teams(filter: { parent: { id: { eq: $parentId } } }, after: $after, first: $first) {
+				nodes {
+					id
+					name
+					key
+					description
+					private
+					parent {
+						id
+						private
+					}
+				}
+				pageInfo {
+					hasPreviousPage
+					hasNextPage
+					startCursor
+					endCursor
+				}
+			}
+		}`
+	b := map[string]interface{}{
+		"query":     query,
+		"variables": getSubTeamsVars,
+	}
+
+	var res GraphQLTeamsResponse
+	resp, rlData, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, "", rlData, err
+	}
+
+	if res.Data.Teams.PageInfo.HasNextPage {
+		return res.Data.Teams.Nodes, res.Data.Teams.PageInfo.EndCursor, rlData, nil
+	}
+
+	return res.Data.Teams.Nodes, "", rlData, nil
+}
+
 // GetProjects returns all projects from Linear organization.
 func (c *Client) GetProjects(ctx context.Context, getResourceVars GetResourcesVars) ([]Project, string, *v2.RateLimitDescription, error) {
 	query := `query Projects($after: String, $first: Int) {
@@ -286,263 +398,1134 @@ func (c *Client) GetProjects(ctx context.Context, getResourceVars GetResourcesVa
 	return res.Data.Projects.Nodes, "", rlData, nil
 }
 
-// GetOrganization returns a single Linear organization.
-func (c *Client) GetOrganization(ctx context.Context, paginationVars PaginationVars) (Organization, Tokens, *v2.RateLimitDescription, error) {
-	query := `query Organization($usersAfter: String, $teamsAfter: String, $first: Int) {
-			organization {
-				id
-				name
-				samlEnabled
-				scimEnabled
-				subscription {
+// GetInitiatives returns all initiatives from Linear organization.
+func (c *Client) GetInitiatives(ctx context.Context, getResourceVars GetResourcesVars) ([]Initiative, string, *v2.RateLimitDescription, error) {
+	query := `query Initiatives($after: String, $first: Int) {
+			initiatives(after: $after, first: $first) {
+				nodes {
 					id
+					name
+					description
+					status
+					url
 				}
-				urlKey
-				userCount
-				teams(after: $teamsAfter, first: $first) {
-					nodes {
-						id
-					}
-					pageInfo {
-						hasPreviousPage
-						hasNextPage
-						startCursor
-						endCursor
-					}
+				pageInfo {
+					hasPreviousPage
+					hasNextPage
+					startCursor
+					endCursor
 				}
-				users(after: $usersAfter, first: $first) {
-					nodes {
+			}
+		}`
+	b := map[string]interface{}{
+		"query":     query,
+		"variables": getResourceVars,
+	}
+
+	var res GraphQLInitiativesResponse
+	resp, rlData, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, "", rlData, err
+	}
+
+	if res.Data.Initiatives.PageInfo.HasNextPage {
+		return res.Data.Initiatives.Nodes, res.Data.Initiatives.PageInfo.EndCursor, rlData, nil
+	}
+
+	return res.Data.Initiatives.Nodes, "", rlData, nil
+}
+
+// GetIntegrations returns the integrations installed in the Linear organization.
+func (c *Client) GetIntegrations(ctx context.Context, getResourceVars GetResourcesVars) ([]Integration, string, *v2.RateLimitDescription, error) {
+	query := `query Integrations($after: String, $first: Int) {
+			integrations(after: $after, first: $first) {
+				nodes {
+					id
+					service
+					createdAt
+					creator {
 						id
-						admin
-						guest
-						owner
+						name
+						app
 					}
-					pageInfo {
-						hasPreviousPage
-						hasNextPage
-						startCursor
-						endCursor
+					team {
+						id
+						name
+						key
+						private
 					}
 				}
+				pageInfo {
+					hasPreviousPage
+					hasNextPage
+					startCursor
+					endCursor
+				}
 			}
 		}`
 	b := map[string]interface{}{
 		"query":     query,
-		"variables": paginationVars,
+		"variables": getResourceVars,
 	}
 
-	var res GraphQLOrganizationResponse
+	var res GraphQLIntegrationsResponse
 	resp, rlData, err := c.doRequest(ctx, b, &res)
 	defer closeResponse(resp)
 	if err != nil {
-		return Organization{}, Tokens{}, rlData, err
-	}
-
-	var tokens Tokens
-
-	if res.Data.Organization.Users.PageInfo.HasNextPage {
-		tokens.UsersToken = res.Data.Organization.Users.PageInfo.EndCursor
+		return nil, "", rlData, err
 	}
 
-	if res.Data.Organization.Teams.PageInfo.HasNextPage {
-		tokens.TeamsToken = res.Data.Organization.Teams.PageInfo.EndCursor
+	if res.Data.Integrations.PageInfo.HasNextPage {
+		return res.Data.Integrations.Nodes, res.Data.Integrations.PageInfo.EndCursor, rlData, nil
 	}
 
-	return res.Data.Organization, tokens, rlData, nil
+	return res.Data.Integrations.Nodes, "", rlData, nil
 }
 
-// GetTeam returns single Team details.
-func (c *Client) GetTeam(ctx context.Context, getTeamVars GetTeamVars) (Team, string, *v2.RateLimitDescription, error) {
-	vars := GetTeamVars{TeamId: getTeamVars.TeamId, First: getTeamVars.First, After: ""}
+// DeleteIntegration uninstalls an integration from the workspace.
+func (c *Client) DeleteIntegration(ctx context.Context, integrationID string) (bool, error) {
+	mutation := `mutation IntegrationDelete($id: String!) {
+			integrationDelete(id: $id) {
+				success
+			}
+		}`
 
-	if getTeamVars.After != "" {
-		vars.After = getTeamVars.After
+	b := map[string]interface{}{
+		"query": mutation,
+		"variables": map[string]interface{}{
+			"id": integrationID,
+		},
 	}
 
-	query := `query Team($teamId: String!, $after: String, $first: Int) {
-			team(id: $teamId) {
-				id
-				name
-				key
-				description
-				memberships(after: $after, first: $first) {
-					nodes {
+	var res struct {
+		Data struct {
+			IntegrationDelete SuccessResponse `json:"integrationDelete"`
+		} `json:"data"`
+	}
+	resp, _, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Data.IntegrationDelete.Success, nil
+}
+
+// GetWebhooks returns the webhooks configured in the Linear organization.
+func (c *Client) GetWebhooks(ctx context.Context, getResourceVars GetResourcesVars) ([]Webhook, string, *v2.RateLimitDescription, error) {
+	query := `query Webhooks($after: String, $first: Int) {
+			webhooks(after: $after, first: $first) {
+				nodes {
+					id
+					label
+					url
+					enabled
+					resourceTypes
+					allPublicTeams
+					createdAt
+					team {
 						id
-						user {
-							id
-						}
-						team {
-							id
-						}
+						name
+						key
+						private
 					}
-					pageInfo {
-						hasPreviousPage
-						hasNextPage
-						startCursor
-						endCursor
+					creator {
+						id
+						name
+						app
 					}
 				}
+				pageInfo {
+					hasPreviousPage
+					hasNextPage
+					startCursor
+					endCursor
+				}
 			}
 		}`
 	b := map[string]interface{}{
 		"query":     query,
-		"variables": vars,
+		"variables": getResourceVars,
 	}
 
-	var res GraphQLTeamResponse
+	var res GraphQLWebhooksResponse
 	resp, rlData, err := c.doRequest(ctx, b, &res)
 	defer closeResponse(resp)
 	if err != nil {
-		return Team{}, "", rlData, err
+		return nil, "", rlData, err
 	}
 
-	if res.Data.Team.Memberships.PageInfo.HasNextPage {
-		return res.Data.Team, res.Data.Team.Memberships.PageInfo.EndCursor, rlData, nil
+	if res.Data.Webhooks.PageInfo.HasNextPage {
+		return res.Data.Webhooks.Nodes, res.Data.Webhooks.PageInfo.EndCursor, rlData, nil
 	}
 
-	return res.Data.Team, "", rlData, nil
+	return res.Data.Webhooks.Nodes, "", rlData, nil
 }
 
-// GetProject returns single Project details.
-func (c *Client) GetProject(ctx context.Context, getProjectVars GetProjectVars) (Project, Tokens, *v2.RateLimitDescription, error) {
-	query := `query Project($projectId: String!, $usersAfter: String, $teamsAfter: String, $first: Int) {
-			project(id: $projectId) {
-				description
-				id
-				name
-				slugId
-				url
-				teams(after: $teamsAfter, first: $first) {
+// DisableWebhook stops a webhook from delivering events without deleting it.
+func (c *Client) DisableWebhook(ctx context.Context, webhookID string) (bool, error) {
+	mutation := `mutation WebhookUpdate($id: String!, $input: WebhookUpdateInput!) {
+			webhookUpdate(id: $id, input: $input) {
+				success
+			}
+		}`
+
+	b := map[string]interface{}{
+		"query": mutation,
+		"variables": map[string]interface{}{
+			"id":    webhookID,
+			"input": map[string]interface{}{"enabled": false},
+		},
+	}
+
+	var res struct {
+		Data struct {
+			WebhookUpdate SuccessResponse `json:"webhookUpdate"`
+		} `json:"data"`
+	}
+	resp, _, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Data.WebhookUpdate.Success, nil
+}
+
+// DeleteWebhook removes a webhook.
+func (c *Client) DeleteWebhook(ctx context.Context, webhookID string) (bool, error) {
+	mutation := `mutation WebhookDelete($id: String!) {
+			webhookDelete(id: $id) {
+				success
+			}
+		}`
+
+	b := map[string]interface{}{
+		"query": mutation,
+		"variables": map[string]interface{}{
+			"id": webhookID,
+		},
+	}
+
+	var res struct {
+		Data struct {
+			WebhookDelete SuccessResponse `json:"webhookDelete"`
+		} `json:"data"`
+	}
+	resp, _, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Data.WebhookDelete.Success, nil
+}
+
+// GetInitiative returns a single initiative with its owner and a page of the
+// projects it includes.
+func (c *Client) GetInitiative(ctx context.Context, getInitiativeVars GetInitiativeVars) (Initiative, string, *v2.RateLimitDescription, error) {
+	query := `query Initiative($initiativeId: String!, $after: String, $first: Int) {
+			initiative(id: $initiativeId) {
+				id
+				name
+				owner {
+					id
+					name
+					email
+					app
+				}
+				projects(after: $after, first: $first) {
+					nodes {
+						id
+						name
+					}
+					pageInfo {
+						hasPreviousPage
+						hasNextPage
+						startCursor
+						endCursor
+					}
+				}
+			}
+		}`
+	b := map[string]interface{}{
+		"query":     query,
+		"variables": getInitiativeVars,
+	}
+
+	var res GraphQLInitiativeResponse
+	resp, rlData, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return Initiative{}, "", rlData, err
+	}
+
+	if res.Data.Initiative.Projects.PageInfo.HasNextPage {
+		return res.Data.Initiative, res.Data.Initiative.Projects.PageInfo.EndCursor, rlData, nil
+	}
+
+	return res.Data.Initiative, "", rlData, nil
+}
+
+// GetOrganization returns a single Linear organization.
+func (c *Client) GetOrganization(ctx context.Context, paginationVars PaginationVars) (Organization, Tokens, *v2.RateLimitDescription, error) {
+	query := `query Organization($usersAfter: String, $teamsAfter: String, $first: Int) {
+			organization {
+				id
+				name
+				samlEnabled
+				scimEnabled
+				subscription {
+					id
+				}
+				urlKey
+				userCount
+				teams(after: $teamsAfter, first: $first) {
+					nodes {
+						id
+						private
+					}
+					pageInfo {
+						hasPreviousPage
+						hasNextPage
+						startCursor
+						endCursor
+					}
+				}
+				users(after: $usersAfter, first: $first) {
+					nodes {
+						id
+						admin
+						app
+						guest
+						owner
+					}
+					pageInfo {
+						hasPreviousPage
+						hasNextPage
+						startCursor
+						endCursor
+					}
+				}
+			}
+		}`
+	b := map[string]interface{}{
+		"query":     query,
+		"variables": paginationVars,
+	}
+
+	var res GraphQLOrganizationResponse
+	resp, rlData, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return Organization{}, Tokens{}, rlData, err
+	}
+
+	var tokens Tokens
+
+	if res.Data.Organization.Users.PageInfo.HasNextPage {
+		tokens.UsersToken = res.Data.Organization.Users.PageInfo.EndCursor
+	}
+
+	if res.Data.Organization.Teams.PageInfo.HasNextPage {
+		tokens.TeamsToken = res.Data.Organization.Teams.PageInfo.EndCursor
+	}
+
+	return res.Data.Organization, tokens, rlData, nil
+}
+
+// GetTeam returns single Team details.
+func (c *Client) GetTeam(ctx context.Context, getTeamVars GetTeamVars) (Team, string, *v2.RateLimitDescription, error) {
+	vars := GetTeamVars{TeamId: getTeamVars.TeamId, First: getTeamVars.First, After: ""}
+
+	if getTeamVars.After != "" {
+		vars.After = getTeamVars.After
+	}
+
+	query := `query Team($teamId: String!, $after: String, $first: Int) {
+			team(id: $teamId) {
+				id
+				name
+				key
+				description
+				private
+				parent {
+					id
+					private
+				}
+				memberships(after: $after, first: $first) {
+					nodes {
+						id
+						owner
+						user {
+							id
+							app
+						}
+						team {
+							id
+						}
+					}
+					pageInfo {
+						hasPreviousPage
+						hasNextPage
+						startCursor
+						endCursor
+					}
+				}
+			}
+		}`
+	b := map[string]interface{}{
+		"query":     query,
+		"variables": vars,
+	}
+
+	var res GraphQLTeamResponse
+	resp, rlData, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return Team{}, "", rlData, err
+	}
+
+	if res.Data.Team.Memberships.PageInfo.HasNextPage {
+		return res.Data.Team, res.Data.Team.Memberships.PageInfo.EndCursor, rlData, nil
+	}
+
+	return res.Data.Team, "", rlData, nil
+}
+
+// GetProject returns single Project details.
+func (c *Client) GetProject(ctx context.Context, getProjectVars GetProjectVars) (Project, Tokens, *v2.RateLimitDescription, error) {
+	query := `query Project($projectId: String!, $usersAfter: String, $teamsAfter: String, $first: Int) {
+			project(id: $projectId) {
+				description
+				id
+				name
+				slugId
+				url
+				lead {
+					id
+					name
+					app
+				}
+				teams(after: $teamsAfter, first: $first) {
+					nodes {
+						id
+						name
+						private
+					}
+					pageInfo {
+						hasPreviousPage
+						hasNextPage
+						startCursor
+						endCursor
+					}
+				}
+				members(after: $usersAfter, first: $first) {
+					nodes {
+						id
+						name
+						app
+					}
+					pageInfo {
+						hasPreviousPage
+						hasNextPage
+						startCursor
+						endCursor
+					}
+				}
+			}
+		}`
+	b := map[string]interface{}{
+		"query":     query,
+		"variables": getProjectVars,
+	}
+
+	var res GraphQLProjectResponse
+	resp, rlData, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return Project{}, Tokens{}, rlData, err
+	}
+
+	var tokens Tokens
+
+	if res.Data.Project.Members.PageInfo.HasNextPage {
+		tokens.UsersToken = res.Data.Project.Members.PageInfo.EndCursor
+	}
+
+	if res.Data.Project.Teams.PageInfo.HasNextPage {
+		tokens.TeamsToken = res.Data.Project.Teams.PageInfo.EndCursor
+	}
+
+	return res.Data.Project, tokens, rlData, nil
+}
+
+// GetProjectMembers returns a page of the members of a single project.
+func (c *Client) GetProjectMembers(ctx context.Context, getProjectVars GetProjectVars) ([]User, string, *v2.RateLimitDescription, error) {
+	query := `query ProjectMembers($projectId: String!, $usersAfter: String, $first: Int) {
+			project(id: $projectId) {
+				id
+				members(after: $usersAfter, first: $first) {
+					nodes {
+						id
+					}
+					pageInfo {
+						hasPreviousPage
+						hasNextPage
+						startCursor
+						endCursor
+					}
+				}
+			}
+		}`
+	b := map[string]interface{}{
+		"query":     query,
+		"variables": getProjectVars,
+	}
+
+	var res GraphQLProjectResponse
+	resp, rlData, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, "", rlData, err
+	}
+
+	if res.Data.Project.Members.PageInfo.HasNextPage {
+		return res.Data.Project.Members.Nodes, res.Data.Project.Members.PageInfo.EndCursor, rlData, nil
+	}
+
+	return res.Data.Project.Members.Nodes, "", rlData, nil
+}
+
+// GetProjectTeams returns a page of the teams associated with a single project.
+func (c *Client) GetProjectTeams(ctx context.Context, getProjectVars GetProjectVars) ([]Team, string, *v2.RateLimitDescription, error) {
+	query := `query ProjectTeams($projectId: String!, $teamsAfter: String, $first: Int) {
+			project(id: $projectId) {
+				id
+				teams(after: $teamsAfter, first: $first) {
+					nodes {
+						id
+						private
+					}
+					pageInfo {
+						hasPreviousPage
+						hasNextPage
+						startCursor
+						endCursor
+					}
+				}
+			}
+		}`
+	b := map[string]interface{}{
+		"query":     query,
+		"variables": getProjectVars,
+	}
+
+	var res GraphQLProjectResponse
+	resp, rlData, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, "", rlData, err
+	}
+
+	if res.Data.Project.Teams.PageInfo.HasNextPage {
+		return res.Data.Project.Teams.Nodes, res.Data.Project.Teams.PageInfo.EndCursor, rlData, nil
+	}
+
+	return res.Data.Project.Teams.Nodes, "", rlData, nil
+}
+
+// Authorize returns permissions of user calling the API.
+func (c *Client) Authorize(ctx context.Context) (ViewerPermissions, *v2.RateLimitDescription, error) {
+	query := `query Viewer{
+			viewer {
+				guest
+				id
+				admin
+				owner
+			}
+		}`
+	b := map[string]interface{}{
+		"query": query,
+	}
+
+	var res GraphQLViewerResponse
+	resp, rlData, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return ViewerPermissions{}, rlData, err
+	}
+
+	return res.Data.Viewer, rlData, nil
+}
+
+func (c *Client) AddMemberToTeam(ctx context.Context, teamId, userId string) (string, error) {
+	mutation := `mutation TeamMembershipCreate($input: TeamMembershipCreateInput!){
+			teamMembershipCreate(input: $input) {
+				success
+				teamMembership {
+					id
+				}
+			}
+		}`
+
+	vars := map[string]interface{}{
+		"input": map[string]interface{}{
+			"teamId": teamId,
+			"userId": userId,
+		},
+	}
+
+	b := map[string]interface{}{
+		"query":     mutation,
+		"variables": vars,
+	}
+
+	var res struct {
+		Data struct {
+			TeamMembershipCreate struct {
+				Success        bool `json:"success"`
+				TeamMembership struct {
+					ID string `json:"id"`
+				} `json:"teamMembership"`
+			} `json:"teamMembershipCreate"`
+		} `json:"data"`
+	}
+	resp, _, e := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if e != nil {
+		return "", e
+	}
+
+	if !res.Data.TeamMembershipCreate.Success {
+		return "", fmt.Errorf("teamMembershipCreate returned success=false")
+	}
+
+	return res.Data.TeamMembershipCreate.TeamMembership.ID, nil
+}
+
+// CreateOrganizationInvite invites a new user to the Linear workspace.
+// Returns the organization invite ID. The user becomes a User in Linear only after accepting.
+func (c *Client) CreateOrganizationInvite(ctx context.Context, email string, role string, teamIDs []string) (string, error) {
+	mutation := `mutation OrganizationInviteCreate($input: OrganizationInviteCreateInput!) {
+			organizationInviteCreate(input: $input) {
+				success
+				organizationInvite {
+					id
+				}
+			}
+		}`
+
+	input := map[string]interface{}{
+		"email": email,
+	}
+	if role != "" {
+		input["role"] = role
+	}
+	if len(teamIDs) > 0 {
+		input["teamIds"] = teamIDs
+	}
+
+	b := map[string]interface{}{
+		"query":     mutation,
+		"variables": map[string]interface{}{"input": input},
+	}
+
+	var res struct {
+		Data struct {
+			OrganizationInviteCreate struct {
+				Success            bool `json:"success"`
+				OrganizationInvite struct {
+					ID string `json:"id"`
+				} `json:"organizationInvite"`
+			} `json:"organizationInviteCreate"`
+		} `json:"data"`
+	}
+	resp, _, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	if !res.Data.OrganizationInviteCreate.Success {
+		return "", fmt.Errorf("organizationInviteCreate returned success=false")
+	}
+
+	return res.Data.OrganizationInviteCreate.OrganizationInvite.ID, nil
+}
+
+// GetOrganizationInvites returns the workspace invites, including ones that
+// have already been accepted or have expired.
+func (c *Client) GetOrganizationInvites(ctx context.Context, getResourceVars GetResourcesVars) ([]OrganizationInvite, string, *v2.RateLimitDescription, error) {
+	query := `query OrganizationInvites($after: String, $first: Int) {
+			organizationInvites(after: $after, first: $first) {
+				nodes {
+					id
+					email
+					role
+					createdAt
+					expiresAt
+					acceptedAt
+					inviter {
+						id
+						name
+						email
+					}
+				}
+				pageInfo {
+					endCursor
+					hasNextPage
+					hasPreviousPage
+					startCursor
+				}
+			}
+		}`
+	b := map[string]interface{}{
+		"query":     query,
+		"variables": getResourceVars,
+	}
+
+	var res GraphQLOrganizationInvitesResponse
+	resp, rlData, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, "", rlData, err
+	}
+
+	if res.Data.OrganizationInvites.PageInfo.HasNextPage {
+		return res.Data.OrganizationInvites.Nodes, res.Data.OrganizationInvites.PageInfo.EndCursor, rlData, nil
+	}
+
+	return res.Data.OrganizationInvites.Nodes, "", rlData, nil
+}
+
+// DeleteOrganizationInvite cancels a pending workspace invite.
+func (c *Client) DeleteOrganizationInvite(ctx context.Context, inviteID string) (bool, error) {
+	mutation := `mutation OrganizationInviteDelete($id: String!) {
+			organizationInviteDelete(id: $id) {
+				success
+			}
+		}`
+
+	b := map[string]interface{}{
+		"query": mutation,
+		"variables": map[string]interface{}{
+			"id": inviteID,
+		},
+	}
+
+	var res struct {
+		Data struct {
+			OrganizationInviteDelete SuccessResponse `json:"organizationInviteDelete"`
+		} `json:"data"`
+	}
+	resp, _, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Data.OrganizationInviteDelete.Success, nil
+}
+
+// ResendOrganizationInvite sends the email for a pending workspace invite again.
+func (c *Client) ResendOrganizationInvite(ctx context.Context, inviteID string) (bool, error) {
+	mutation := `mutation ResendOrganizationInvite($id: String!) {
+			resendOrganizationInvite(id: $id) {
+				success
+			}
+		}`
+
+	b := map[string]interface{}{
+		"query": mutation,
+		"variables": map[string]interface{}{
+			"id": inviteID,
+		},
+	}
+
+	var res struct {
+		Data struct {
+			ResendOrganizationInvite SuccessResponse `json:"resendOrganizationInvite"`
+		} `json:"data"`
+	}
+	resp, _, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Data.ResendOrganizationInvite.Success, nil
+}
+
+// GetAuthorizedApplications returns the OAuth applications authorized in the
+// workspace along with the users who authorized each one. Linear returns the
+// full list in one response.
+func (c *Client) GetAuthorizedApplications(ctx context.Context) ([]AuthorizedApplication, *v2.RateLimitDescription, error) {
+	query := `query WorkspaceAuthorizedApplications {
+			workspaceAuthorizedApplications {
+				appId
+				clientId
+				name
+				description
+				imageUrl
+				developer
+				developerUrl
+				scope
+				memberships {
+					userId
+					createdAt
+					lastActiveAt
+				}
+			}
+		}`
+
+	b := map[string]interface{}{
+		"query": query,
+	}
+
+	var res GraphQLAuthorizedApplicationsResponse
+	resp, rlData, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, rlData, err
+	}
+
+	return res.Data.WorkspaceAuthorizedApplications, rlData, nil
+}
+
+// RevokeAuthorizedApplication removes an OAuth application's authorization for
+// every user in the workspace.
+func (c *Client) RevokeAuthorizedApplication(ctx context.Context, appID string) (bool, error) {
+	mutation := `mutation WorkspaceAuthorizedApplicationRevoke($appId: String!) {
+			workspaceAuthorizedApplicationRevoke(appId: $appId) {
+				success
+			}
+		}`
+
+	b := map[string]interface{}{
+		"query": mutation,
+		"variables": map[string]interface{}{
+			"appId": appID,
+		},
+	}
+
+	var res struct {
+		Data struct {
+			WorkspaceAuthorizedApplicationRevoke SuccessResponse `json:"workspaceAuthorizedApplicationRevoke"`
+		} `json:"data"`
+	}
+	resp, _, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Data.WorkspaceAuthorizedApplicationRevoke.Success, nil
+}
+
+// SuspendUser deactivates a user's account, revoking their access to the workspace
+// and invalidating their sessions. Reversible via UnsuspendUser. Requires admin/owner.
+func (c *Client) SuspendUser(ctx context.Context, userID string) (bool, error) {
+	mutation := `mutation UserSuspend($id: String!) {
+			userSuspend(id: $id) {
+				success
+			}
+		}`
+
+	b := map[string]interface{}{
+		"query":     mutation,
+		"variables": map[string]interface{}{"id": userID},
+	}
+
+	var res struct {
+		Data struct {
+			UserSuspend SuccessResponse `json:"userSuspend"`
+		} `json:"data"`
+	}
+	resp, _, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Data.UserSuspend.Success, nil
+}
+
+// UnsuspendUser reactivates a user previously suspended with SuspendUser,
+// restoring their access to the workspace. Requires admin/owner.
+func (c *Client) UnsuspendUser(ctx context.Context, userID string) (bool, error) {
+	mutation := `mutation UserUnsuspend($id: String!) {
+			userUnsuspend(id: $id) {
+				success
+			}
+		}`
+
+	b := map[string]interface{}{
+		"query":     mutation,
+		"variables": map[string]interface{}{"id": userID},
+	}
+
+	var res struct {
+		Data struct {
+			UserUnsuspend SuccessResponse `json:"userUnsuspend"`
+		} `json:"data"`
+	}
+	resp, _, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Data.UserUnsuspend.Success, nil
+}
+
+// GetUser returns a single Linear user.
+func (c *Client) GetUser(ctx context.Context, userID string) (User, *v2.RateLimitDescription, error) {
+	query := `query User($id: String!) {
+			user(id: $id) {
+				active
+				admin
+				app
+				email
+				guest
+				id
+				name
+				owner
+			}
+		}`
+	b := map[string]interface{}{
+		"query":     query,
+		"variables": map[string]interface{}{"id": userID},
+	}
+
+	var res GraphQLUserResponse
+	resp, rlData, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return User{}, rlData, err
+	}
+
+	return res.Data.User, rlData, nil
+}
+
+// GetUserByEmail looks up a user, suspended or not, by email address. It
+// returns nil when no user has that email.
+func (c *Client) GetUserByEmail(ctx context.Context, email string) (*User, *v2.RateLimitDescription, error) {
+	query := `query UserByEmail($email: String!) {
+			users(filter: { email: { eqIgnoreCase: $email } }, first: 1, includeDisabled: true) {
+				nodes {
+					active
+					admin
+					app
+					displayName
+					email
+					guest
+					id
+					name
+					organization {
+						id
+					}
+					owner
+				}
+			}
+		}`
+	b := map[string]interface{}{
+		"query":     query,
+		"variables": map[string]interface{}{"email": email},
+	}
+
+	var res GraphQLUsersResponse
+	resp, rlData, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, rlData, err
+	}
+
+	if len(res.Data.Users.Nodes) == 0 {
+		return nil, rlData, nil
+	}
+
+	return &res.Data.Users.Nodes[0], rlData, nil
+}
+
+// SetUserRole sets a user's workspace role to one of guest, user, admin or
+// owner. Requires admin, and owner to grant or take away the owner role.
+func (c *Client) SetUserRole(ctx context.Context, userID string, role string) (bool, error) {
+	mutation := `mutation UserChangeRole($id: String!, $role: UserRoleType!) {
+			userChangeRole(id: $id, role: $role) {
+				success
+			}
+		}`
+
+	b := map[string]interface{}{
+		"query": mutation,
+		"variables": map[string]interface{}{
+			"id":   userID,
+			"role": role,
+		},
+	}
+
+	var res struct {
+		Data struct {
+			UserChangeRole SuccessResponse `json:"userChangeRole"`
+		} `json:"data"`
+	}
+	resp, _, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Data.UserChangeRole.Success, nil
+}
+
+// GetTeamMembership returns the user's membership of the team, or nil if the
+// user isn't a member. It pages through the user's memberships, which are far
+// fewer than the team's.
+func (c *Client) GetTeamMembership(ctx context.Context, teamID string, userID string) (*TeamMembership, error) {
+	query := `query UserTeamMemberships($userId: String!, $after: String, $first: Int) {
+			user(id: $userId) {
+				teamMemberships(after: $after, first: $first) {
 					nodes {
 						id
-						name
+						owner
+						user {
+							id
+						}
+						team {
+							id
+						}
 					}
 					pageInfo {
-						hasPreviousPage
-						hasNextPage
-						startCursor
 						endCursor
-					}
-				}
-				members(after: $usersAfter, first: $first) {
-					nodes {
-						id
-						name
-					}
-					pageInfo {
-						hasPreviousPage
 						hasNextPage
-						startCursor
-						endCursor
 					}
 				}
 			}
 		}`
-	b := map[string]interface{}{
-		"query":     query,
-		"variables": getProjectVars,
-	}
 
-	var res GraphQLProjectResponse
-	resp, rlData, err := c.doRequest(ctx, b, &res)
-	defer closeResponse(resp)
-	if err != nil {
-		return Project{}, Tokens{}, rlData, err
+	vars := map[string]interface{}{
+		"userId": userID,
+		"first":  100,
 	}
+	for {
+		b := map[string]interface{}{
+			"query":     query,
+			"variables": vars,
+		}
 
-	var tokens Tokens
-
-	if res.Data.Project.Members.PageInfo.HasNextPage {
-		tokens.UsersToken = res.Data.Project.Members.PageInfo.EndCursor
-	}
+		var res struct {
+			Data struct {
+				User struct {
+					TeamMemberships struct {
+						Nodes    []TeamMembership `json:"nodes"`
+						PageInfo PageInfo         `json:"pageInfo"`
+					} `json:"teamMemberships"`
+				} `json:"user"`
+			} `json:"data"`
+		}
+		resp, _, err := c.doRequest(ctx, b, &res)
+		closeResponse(resp)
+		if err != nil {
+			return nil, err
+		}
 
-	if res.Data.Project.Teams.PageInfo.HasNextPage {
-		tokens.TeamsToken = res.Data.Project.Teams.PageInfo.EndCursor
+		memberships := res.Data.User.TeamMemberships
+		for _, membership := range memberships.Nodes {
+			if membership.Team.ID == teamID {
+				return &membership, nil
+			}
+		}
+		if !memberships.PageInfo.HasNextPage {
+			return nil, nil
+		}
+		vars["after"] = memberships.PageInfo.EndCursor
 	}
-
-	return res.Data.Project, tokens, rlData, nil
 }
 
-// Authorize returns permissions of user calling the API.
-func (c *Client) Authorize(ctx context.Context) (ViewerPermissions, *v2.RateLimitDescription, error) {
-	query := `query Viewer{
-			viewer {
-				guest
-				id
-				admin
-				owner
+// UpdateTeamMembership sets whether a team member is an owner of the team.
+func (c *Client) UpdateTeamMembership(ctx context.Context, teamMembershipID string, owner bool) (bool, error) {
+	mutation := `mutation TeamMembershipUpdate($id: String!, $input: TeamMembershipUpdateInput!) {
+			teamMembershipUpdate(id: $id, input: $input) {
+				success
 			}
 		}`
+
 	b := map[string]interface{}{
-		"query": query,
+		"query": mutation,
+		"variables": map[string]interface{}{
+			"id":    teamMembershipID,
+			"input": map[string]interface{}{"owner": owner},
+		},
 	}
 
-	var res GraphQLViewerResponse
-	resp, rlData, err := c.doRequest(ctx, b, &res)
+	var res struct {
+		Data struct {
+			TeamMembershipUpdate SuccessResponse `json:"teamMembershipUpdate"`
+		} `json:"data"`
+	}
+	resp, _, err := c.doRequest(ctx, b, &res)
 	defer closeResponse(resp)
 	if err != nil {
-		return ViewerPermissions{}, rlData, err
+		return false, err
 	}
 
-	return res.Data.Viewer, rlData, nil
+	return res.Data.TeamMembershipUpdate.Success, nil
 }
 
-func (c *Client) AddMemberToTeam(ctx context.Context, teamId, userId string) (string, error) {
-	mutation := `mutation TeamMembershipCreate($input: TeamMembershipCreateInput!){
-			teamMembershipCreate(input: $input) {
+func (c *Client) RemoveTeamMembership(ctx context.Context, teamMembershipId string) (bool, error) {
+	mutation := `mutation TeamMembershipDelete($teamMembershipDeleteId: String!){
+			teamMembershipDelete(id: $teamMembershipDeleteId) {
 				success
-				teamMembership {
-					id
-				}
 			}
 		}`
 
-	vars := map[string]interface{}{
-		"input": map[string]interface{}{
-			"teamId": teamId,
-			"userId": userId,
-		},
-	}
-
 	b := map[string]interface{}{
-		"query":     mutation,
-		"variables": vars,
+		"query": mutation,
+		"variables": map[string]interface{}{
+			"teamMembershipDeleteId": teamMembershipId,
+		},
 	}
 
 	var res struct {
-		TeamMembership struct {
-			ID string `json:"id"`
-		} `json:"teamMembership"`
+		Data struct {
+			TeamMembershipDelete SuccessResponse `json:"teamMembershipDelete"`
+		} `json:"data"`
 	}
-	resp, _, e := c.doRequest(ctx, b, &res)
+	resp, _, err := c.doRequest(ctx, b, &res)
 	defer closeResponse(resp)
-	if e != nil {
-		return "", e
+	if err != nil {
+		return false, err
 	}
 
-	return res.TeamMembership.ID, nil
+	return res.Data.TeamMembershipDelete.Success, nil
 }
 
-// CreateOrganizationInvite invites a new user to the Linear workspace.
-// Returns the organization invite ID. The user becomes a User in Linear only after accepting.
-func (c *Client) CreateOrganizationInvite(ctx context.Context, email string, role string, teamIDs []string) (string, error) {
-	mutation := `mutation OrganizationInviteCreate($input: OrganizationInviteCreateInput!) {
-			organizationInviteCreate(input: $input) {
+// CreateTeam creates a new team. Linear derives a key from the name when key
+// is empty.
+func (c *Client) CreateTeam(ctx context.Context, name string, key string, description string) (Team, error) {
+	mutation := `mutation TeamCreate($input: TeamCreateInput!) {
+			teamCreate(input: $input) {
 				success
-				organizationInvite {
+				team {
 					id
+					name
+					key
+					description
+					private
 				}
 			}
 		}`
 
 	input := map[string]interface{}{
-		"email": email,
+		"name": name,
 	}
-	if role != "" {
-		input["role"] = role
+	if key != "" {
+		input["key"] = key
 	}
-	if len(teamIDs) > 0 {
-		input["teamIds"] = teamIDs
+	if description != "" {
+		input["description"] = description
 	}
 
 	b := map[string]interface{}{
@@ -552,44 +1535,43 @@ func (c *Client) CreateOrganizationInvite(ctx context.Context, email string, rol
 
 	var res struct {
 		Data struct {
-			OrganizationInviteCreate struct {
-				Success            bool `json:"success"`
-				OrganizationInvite struct {
-					ID string `json:"id"`
-				} `json:"organizationInvite"`
-			} `json:"organizationInviteCreate"`
+			TeamCreate struct {
+				Success bool `json:"success"`
+				Team    Team `json:"team"`
+			} `json:"teamCreate"`
 		} `json:"data"`
 	}
 	resp, _, err := c.doRequest(ctx, b, &res)
 	defer closeResponse(resp)
 	if err != nil {
-		return "", err
+		return Team{}, err
 	}
 
-	if !res.Data.OrganizationInviteCreate.Success {
-		return "", fmt.Errorf("organizationInviteCreate returned success=false")
+	if !res.Data.TeamCreate.Success {
+		return Team{}, fmt.Errorf("teamCreate returned success=false")
 	}
 
-	return res.Data.OrganizationInviteCreate.OrganizationInvite.ID, nil
+	return res.Data.TeamCreate.Team, nil
 }
 
-// SuspendUser deactivates a user's account, revoking their access to the workspace
-// and invalidating their sessions. Reversible via UnsuspendUser. Requires admin/owner.
-func (c *Client) SuspendUser(ctx context.Context, userID string) (bool, error) {
-	mutation := `mutation UserSuspend($id: String!) {
-			userSuspend(id: $id) {
+// DeleteTeam archives a team. UnarchiveTeam reverses it.
+func (c *Client) DeleteTeam(ctx context.Context, teamID string) (bool, error) {
+	mutation := `mutation TeamDelete($id: String!) {
+			teamDelete(id: $id) {
 				success
 			}
 		}`
 
 	b := map[string]interface{}{
-		"query":     mutation,
-		"variables": map[string]interface{}{"id": userID},
+		"query": mutation,
+		"variables": map[string]interface{}{
+			"id": teamID,
+		},
 	}
 
 	var res struct {
 		Data struct {
-			UserSuspend SuccessResponse `json:"userSuspend"`
+			TeamDelete SuccessResponse `json:"teamDelete"`
 		} `json:"data"`
 	}
 	resp, _, err := c.doRequest(ctx, b, &res)
@@ -598,12 +1580,13 @@ func (c *Client) SuspendUser(ctx context.Context, userID string) (bool, error) {
 		return false, err
 	}
 
-	return res.Data.UserSuspend.Success, nil
+	return res.Data.TeamDelete.Success, nil
 }
 
-func (c *Client) RemoveTeamMembership(ctx context.Context, teamMembershipId string) (bool, error) {
-	mutation := `mutation TeamMembershipDelete($teamMembershipDeleteId: String!){
-			teamMembershipDelete(id: $teamMembershipDeleteId) {
+// UnarchiveTeam restores an archived team and cancels its deletion.
+func (c *Client) UnarchiveTeam(ctx context.Context, teamID string) (bool, error) {
+	mutation := `mutation TeamUnarchive($id: String!) {
+			teamUnarchive(id: $id) {
 				success
 			}
 		}`
@@ -611,13 +1594,13 @@ func (c *Client) RemoveTeamMembership(ctx context.Context, teamMembershipId stri
 	b := map[string]interface{}{
 		"query": mutation,
 		"variables": map[string]interface{}{
-			"teamMembershipDeleteId": teamMembershipId,
+			"id": teamID,
 		},
 	}
 
 	var res struct {
 		Data struct {
-			TeamMembershipDelete SuccessResponse `json:"teamMembershipDelete"`
+			TeamUnarchive SuccessResponse `json:"teamUnarchive"`
 		} `json:"data"`
 	}
 	resp, _, err := c.doRequest(ctx, b, &res)
@@ -626,7 +1609,81 @@ func (c *Client) RemoveTeamMembership(ctx context.Context, teamMembershipId stri
 		return false, err
 	}
 
-	return res.Data.TeamMembershipDelete.Success, nil
+	return res.Data.TeamUnarchive.Success, nil
+}
+
+// GetProjectLead returns the lead of a project, or nil if it has none.
+func (c *Client) GetProjectLead(ctx context.Context, projectID string) (*User, error) {
+	query := `query ProjectLead($projectId: String!) {
+			project(id: $projectId) {
+				id
+				lead {
+					id
+				}
+			}
+		}`
+	b := map[string]interface{}{
+		"query":     query,
+		"variables": map[string]interface{}{"projectId": projectID},
+	}
+
+	var res GraphQLProjectResponse
+	resp, _, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data.Project.Lead, nil
+}
+
+// UpdateProjectLead makes a user the lead of a project.
+func (c *Client) UpdateProjectLead(ctx context.Context, projectID string, leadID string) (bool, error) {
+	return c.updateProject(ctx, projectID, map[string]interface{}{"leadId": leadID})
+}
+
+// UpdateProjectMembers replaces the member list of a project. Linear has no
+// mutation to add or remove a single member, so callers must send the full list.
+func (c *Client) UpdateProjectMembers(ctx context.Context, projectID string, memberIDs []string) (bool, error) {
+	if memberIDs == nil {
+		memberIDs = []string{}
+	}
+	return c.updateProject(ctx, projectID, map[string]interface{}{"memberIds": memberIDs})
+}
+
+// UpdateProjectTeams replaces the teams associated with a project. Linear
+// requires every project to belong to at least one team.
+func (c *Client) UpdateProjectTeams(ctx context.Context, projectID string, teamIDs []string) (bool, error) {
+	return c.updateProject(ctx, projectID, map[string]interface{}{"teamIds": teamIDs})
+}
+
+func (c *Client) updateProject(ctx context.Context, projectID string, input map[string]interface{}) (bool, error) {
+	mutation := `mutation ProjectUpdate($id: String!, $input: ProjectUpdateInput!) {
+			projectUpdate(id: $id, input: $input) {
+				success
+			}
+		}`
+
+	b := map[string]interface{}{
+		"query": mutation,
+		"variables": map[string]interface{}{
+			"id":    projectID,
+			"input": input,
+		},
+	}
+
+	var res struct {
+		Data struct {
+			ProjectUpdate SuccessResponse `json:"projectUpdate"`
+		} `json:"data"`
+	}
+	resp, _, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Data.ProjectUpdate.Success, nil
 }
 
 // GetTeamMemberships returns team memberships from Linear organization.
@@ -973,6 +2030,85 @@ func (c *Client) ListIssuesByIDs(ctx context.Context, issueIDs []string) ([]Issu
 	return res.Data.Issues.Nodes, rlData, nil
 }
 
+// GetAssignedIssues returns the issues assigned to a user whose workflow state
+// isn't one of the given types.
+func (c *Client) GetAssignedIssues(ctx context.Context, getAssignedIssuesVars GetAssignedIssuesVars) ([]Issue, string, *v2.RateLimitDescription, error) {
+	query := `query AssignedIssues($userId: ID!, $excludedStates: [String!], $after: String, $first: Int) {
+		issues(after: $after, first: $first, filter: {
+			assignee: { id: { eq: $userId } }
+			state: { type: { nin: $excludedStates } }
+		}) {
+			nodes {
+				id
+				title
+				state {
+					id
+					name
+				}
+				url
+			}
+			pageInfo {
+				endCursor
+				hasNextPage
+			}
+		}
+	}`
+
+	b := map[string]interface{}{
+		"query":     query,
+		"variables": getAssignedIssuesVars,
+	}
+
+	var res GraphQLIssuesResponse
+	resp, rlData, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, "", rlData, err
+	}
+
+	if res.Data.Issues.PageInfo.HasNextPage {
+		return res.Data.Issues.Nodes, res.Data.Issues.PageInfo.EndCursor, rlData, nil
+	}
+
+	return res.Data.Issues.Nodes, "", rlData, nil
+}
+
+// UpdateIssuesAssignee assigns the issues to a user, or unassigns them when
+// assigneeID is empty. Linear caps each batch at 50 issues.
+func (c *Client) UpdateIssuesAssignee(ctx context.Context, issueIDs []string, assigneeID string) (bool, error) {
+	mutation := `mutation IssueBatchUpdate($ids: [UUID!]!, $input: IssueUpdateInput!) {
+			issueBatchUpdate(ids: $ids, input: $input) {
+				success
+			}
+		}`
+
+	var assignee interface{}
+	if assigneeID != "" {
+		assignee = assigneeID
+	}
+
+	b := map[string]interface{}{
+		"query": mutation,
+		"variables": map[string]interface{}{
+			"ids":   issueIDs,
+			"input": map[string]interface{}{"assigneeId": assignee},
+		},
+	}
+
+	var res struct {
+		Data struct {
+			IssueBatchUpdate SuccessResponse `json:"issueBatchUpdate"`
+		} `json:"data"`
+	}
+	resp, _, err := c.doRequest(ctx, b, &res)
+	defer closeResponse(resp)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Data.IssueBatchUpdate.Success, nil
+}
+
 func (c *Client) GetIssueLabel(ctx context.Context, labelName string) (*IssueLabel, *v2.RateLimitDescription, error) {
 	query := `query IssueLabel($labelName: String!) {
 		issueLabels(filter: {
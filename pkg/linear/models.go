@@ -45,6 +45,7 @@ type Organization struct {
 type User struct {
 	Active       bool         `json:"active"`
 	Admin        bool         `json:"admin"`
+	App          bool         `json:"app"`
 	DisplayName  string       `json:"displayName"`
 	Email        string       `json:"email"`
 	Guest        bool         `json:"guest"`
@@ -65,6 +66,8 @@ type Team struct {
 	Name        string      `json:"name"`
 	Key         string      `json:"key"`
 	Description interface{} `json:"description"`
+	Private     bool        `json:"private"`
+	Parent      *Team       `json:"parent"`
 	Memberships struct {
 		Nodes    []TeamMembership `json:"nodes"`
 		PageInfo PageInfo         `json:"pageInfo"`
@@ -81,6 +84,7 @@ type Project struct {
 	Name        string `json:"name"`
 	SlugID      string `json:"slugId"`
 	URL         string `json:"url"`
+	Lead        *User  `json:"lead"`
 	Teams       struct {
 		Nodes    []Team   `json:"nodes"`
 		PageInfo PageInfo `json:"pageInfo"`
@@ -91,6 +95,58 @@ type Project struct {
 	} `json:"members"`
 }
 
+type Initiatives struct {
+	Nodes    []Initiative `json:"nodes"`
+	PageInfo PageInfo     `json:"pageInfo"`
+}
+
+type Initiative struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	URL         string `json:"url"`
+	Owner       *User  `json:"owner"`
+	Projects    struct {
+		Nodes    []Project `json:"nodes"`
+		PageInfo PageInfo  `json:"pageInfo"`
+	} `json:"projects"`
+}
+
+type Integrations struct {
+	Nodes    []Integration `json:"nodes"`
+	PageInfo PageInfo      `json:"pageInfo"`
+}
+
+// Integration is a service such as Slack or GitHub installed in the workspace.
+// Team is set when the integration is scoped to a single team.
+type Integration struct {
+	ID        string    `json:"id"`
+	Service   string    `json:"service"`
+	CreatedAt time.Time `json:"createdAt"`
+	Creator   *User     `json:"creator"`
+	Team      *Team     `json:"team"`
+}
+
+type Webhooks struct {
+	Nodes    []Webhook `json:"nodes"`
+	PageInfo PageInfo  `json:"pageInfo"`
+}
+
+// Webhook delivers workspace events to an external URL. Team is set when the
+// webhook is scoped to a single team.
+type Webhook struct {
+	ID             string    `json:"id"`
+	Label          string    `json:"label"`
+	URL            string    `json:"url"`
+	Enabled        bool      `json:"enabled"`
+	ResourceTypes  []string  `json:"resourceTypes"`
+	AllPublicTeams bool      `json:"allPublicTeams"`
+	Team           *Team     `json:"team"`
+	Creator        *User     `json:"creator"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
 type GraphQLError struct {
 	Error  string `json:"error"`
 	Errors []struct {
@@ -131,10 +187,47 @@ type Tokens struct {
 	TeamsToken string `json:"teamsToken,omitempty"`
 }
 
+type OrganizationInvites struct {
+	Nodes    []OrganizationInvite `json:"nodes"`
+	PageInfo PageInfo             `json:"pageInfo"`
+}
+
+type OrganizationInvite struct {
+	ID         string     `json:"id"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	Inviter    User       `json:"inviter"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	AcceptedAt *time.Time `json:"acceptedAt"`
+}
+
+// AuthorizedApplication is a third-party OAuth application that members of
+// the workspace have authorized.
+type AuthorizedApplication struct {
+	AppID        string           `json:"appId"`
+	ClientID     string           `json:"clientId"`
+	Name         string           `json:"name"`
+	Description  string           `json:"description"`
+	ImageURL     string           `json:"imageUrl"`
+	Developer    string           `json:"developer"`
+	DeveloperURL string           `json:"developerUrl"`
+	Scope        []string         `json:"scope"`
+	Memberships  []AuthMembership `json:"memberships"`
+}
+
+// AuthMembership records a user's authorization of an OAuth application.
+type AuthMembership struct {
+	UserID       string     `json:"userId"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	LastActiveAt *time.Time `json:"lastActiveAt"`
+}
+
 type TeamMembership struct {
-	ID   string `json:"id"`
-	User User   `json:"user"`
-	Team Team   `json:"team"`
+	ID    string `json:"id"`
+	Owner bool   `json:"owner"`
+	User  User   `json:"user"`
+	Team  Team   `json:"team"`
 }
 
 type WorkflowType string